@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/zeitgeist/dependency"
+	"sigs.k8s.io/zeitgeist/pkg/osv"
+)
+
+func addAudit(topLevel *cobra.Command) {
+	vo := rootOpts
+
+	cmd := &cobra.Command{
+		Use:           "audit",
+		Short:         "Check current versions of dependencies for known vulnerabilities",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PreRunE: func(*cobra.Command, []string) error {
+			if err := vo.setAndValidate(); err != nil {
+				return err
+			}
+			return vo.validateAudit()
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runAudit(vo)
+		},
+	}
+
+	addAuditFlags(cmd, vo)
+
+	topLevel.AddCommand(cmd)
+}
+
+func addAuditFlags(cmd *cobra.Command, opts *options) {
+	cmd.Flags().StringVar(
+		&opts.osvDatabase,
+		"osv-database",
+		"",
+		"OSV zip export to audit against without network access, e.g. Go/all.zip from https://osv-vulnerabilities.storage.googleapis.com",
+	)
+
+	cmd.Flags().StringVar(
+		&opts.osvServer,
+		"osv-server",
+		osv.DefaultServer,
+		"OSV API server, ignored if --osv-database is set",
+	)
+}
+
+// runAudit is the function invoked by 'addAudit', responsible for checking
+// dependencies against OSV advisories. It fails if any dependency is
+// vulnerable.
+func runAudit(opts *options) error {
+	var db osv.Database = osv.New(opts.osvServer)
+	if opts.osvDatabase != "" {
+		offline, err := osv.Open(opts.osvDatabase)
+		if err != nil {
+			return err
+		}
+		db = offline
+	}
+
+	results, err := dependency.Audit(opts.configFile, db)
+	if err != nil {
+		return fmt.Errorf("auditing dependencies: %w", err)
+	}
+
+	for _, result := range results {
+		fmt.Println(result)
+		for _, vuln := range result.Vulnerabilities {
+			fmt.Printf("  %s: %s\n", vuln.ID, vuln.Summary)
+		}
+	}
+
+	if len(results) > 0 {
+		return fmt.Errorf("found %d vulnerable dependencies", len(results))
+	}
+
+	return nil
+}
@@ -84,6 +84,7 @@ func AddCommands(topLevel *cobra.Command) {
 	addExport(topLevel)
 	addUpgrade(topLevel)
 	addSetVersion(topLevel)
+	addAudit(topLevel)
 }
 
 func initLogging(*cobra.Command, []string) error {
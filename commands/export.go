@@ -95,6 +95,8 @@ func addExport(topLevel *cobra.Command) {
 		"format of the output. Supported values are 'log', 'json' and 'yaml'. If not provided it will default to printing log.",
 	)
 
+	addChangelogFlag(cmd, exo.rootOpts)
+
 	cmd.PersistentFlags().StringVar(
 		&exportOpts.outputFile,
 		"output-file",
@@ -108,47 +110,84 @@ func addExport(topLevel *cobra.Command) {
 // runValidate is the function invoked by 'addValidate', responsible for
 // validating dependencies in a specified configuration file.
 func runExport(opts *exportOptions) error {
-	client, err := dependency.NewRemoteClient()
+	client, err := dependency.NewRemoteClient(clientOptions(opts.rootOpts)...)
 	if err != nil {
 		return err
 	}
 
-	updates, err := client.RemoteExport(opts.rootOpts.configFile)
+	export, err := client.RemoteExport(opts.rootOpts.configFile)
 	if err != nil {
 		return err
 	}
 
-	return output(opts, updates)
+	return output(opts, export)
 }
 
-func output(opts *exportOptions, updates []dependency.VersionUpdate) error {
+func output(opts *exportOptions, export *dependency.Export) error {
 	if OutputFormat(opts.outputFormat) == LOG {
-		return outputLog(updates)
+		return outputLog(export)
+	}
+
+	// Output files only list updates, withheld dependencies are logged
+	for _, line := range withheldLines(export.Withheld) {
+		logrus.Info(line)
 	}
-	return outputFile(opts, updates)
+	return outputFile(opts, export.Updates)
 }
 
-func outputLog(updates []dependency.VersionUpdate) error {
-	for _, update := range updates {
-		if update.Version == update.NewVersion {
-			logrus.Debugf(
-				"No update available for dependency %v: %v (latest: %v)\n",
-				update.Name,
-				update.Version,
-				update.NewVersion,
-			)
-		} else {
+func outputLog(export *dependency.Export) error {
+	for _, update := range export.Updates {
+		fmt.Printf(
+			"Update available for dependency %v: %v (current: %v)\n",
+			update.Name,
+			update.NewVersion,
+			update.Version,
+		)
+
+		if update.Staleness != nil && update.Staleness.NewerReleases > 0 {
+			fmt.Printf("Dependency %v is %v\n", update.Name, update.Staleness)
+		}
+
+		if update.Changelog != "" {
+			fmt.Printf("\n%v\n", update.Changelog)
+		}
+
+		for _, skipped := range update.Skipped {
 			fmt.Printf(
-				"Update available for dependency %v: %v (current: %v)\n",
+				"Skipped version %v for dependency %v: %v\n",
+				skipped.Version,
 				update.Name,
-				update.NewVersion,
-				update.Version,
+				skipped.Reason,
 			)
 		}
 	}
+
+	for _, line := range withheldLines(export.Withheld) {
+		fmt.Println(line)
+	}
 	return nil
 }
 
+// withheldLines describes the dependencies without update which have skipped
+// versions or are held.
+func withheldLines(withheld []dependency.VersionUpdate) []string {
+	var lines []string
+	for _, update := range withheld {
+		if update.Staleness != nil && update.Staleness.NewerReleases > 0 {
+			lines = append(lines, fmt.Sprintf("Dependency %v is %v", update.Name, update.Staleness))
+		}
+
+		for _, skipped := range update.Skipped {
+			lines = append(lines, fmt.Sprintf("Skipped version %v for dependency %v: %v", skipped.Version, update.Name, skipped.Reason))
+		}
+
+		if update.Hold != nil {
+			lines = append(lines, fmt.Sprintf("Dependency %v is %v", update.Name, update.Hold))
+		}
+	}
+	return lines
+}
+
 func outputFile(opts *exportOptions, updates []dependency.VersionUpdate) error {
 	var outputFile string
 	if opts.outputFile != "" {
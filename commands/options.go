@@ -17,10 +17,16 @@ limitations under the License.
 package commands
 
 import (
+	"errors"
+	"fmt"
+	"net/mail"
 	"os"
 	"path/filepath"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/zeitgeist/dependency"
 )
 
 type options struct {
@@ -33,6 +39,64 @@ type options struct {
 
 	// command options
 	logLevel string
+
+	// include release notes of updates in export and upgrade
+	withChangelog bool
+
+	// pull request options
+	openPR      bool
+	pullRequest dependency.PullRequestOptions
+	prRepo      string
+
+	// commit options
+	commit         bool
+	combineCommits bool
+	commitMessage  string
+	commitTrailers []string
+	commitAuthor   string
+
+	// audit options
+	audit       bool
+	osvDatabase string
+	osvServer   string
+}
+
+// clientOptions returns the client options matching the command options.
+func clientOptions(o *options) []dependency.ClientOption {
+	var opts []dependency.ClientOption
+	if o.withChangelog {
+		opts = append(opts, dependency.WithChangelog())
+	}
+	return opts
+}
+
+// author returns the name and email of the commit author, if set.
+func (o *options) author() (string, string, error) {
+	if o.commitAuthor == "" {
+		return "", "", nil
+	}
+
+	author, err := mail.ParseAddress(o.commitAuthor)
+	if err != nil {
+		return "", "", fmt.Errorf("--commit-author should be in the form \"Name <email>\": %w", err)
+	}
+	return author.Name, author.Address, nil
+}
+
+// committer returns a committer of upgrades matching the command options.
+func (o *options) committer() (*dependency.Committer, error) {
+	commitOptions := &dependency.CommitOptions{
+		MessageTemplate: o.commitMessage,
+		Trailers:        o.commitTrailers,
+		Combine:         o.combineCommits,
+	}
+
+	var err error
+	if commitOptions.Author.UserName, commitOptions.Author.Email, err = o.author(); err != nil {
+		return nil, err
+	}
+
+	return dependency.NewCommitter(o.basePath, commitOptions)
 }
 
 // setAndValidate sets some default options and verifies if options are valid.
@@ -53,3 +117,51 @@ func (o *options) setAndValidate() error {
 
 	return nil
 }
+
+// validateAudit verifies that dependencies can be audited with the options:
+// the OSV API is not queried with --local-only, so an OSV export is required.
+func (o *options) validateAudit() error {
+	if o.localOnly && o.osvDatabase == "" {
+		return errors.New("auditing with --local-only requires --osv-database, as the OSV API cannot be queried")
+	}
+	return nil
+}
+
+func addChangelogFlag(cmd *cobra.Command, opts *options) {
+	cmd.Flags().BoolVar(
+		&opts.withChangelog,
+		"with-changelog",
+		false,
+		"include the release notes of every version between the current and latest ones, for flavours which know them",
+	)
+}
+
+func addCommitFlags(cmd *cobra.Command, opts *options) {
+	cmd.Flags().BoolVar(
+		&opts.commit,
+		"commit",
+		false,
+		"commit the files changed by each upgrade, and only those, in a commit per dependency",
+	)
+
+	cmd.Flags().StringVar(
+		&opts.commitMessage,
+		"commit-message",
+		"",
+		"text/template of commit messages, executed with .Name, .Current, .Latest and .Upgrades (see the README for the default)",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&opts.commitTrailers,
+		"commit-trailer",
+		nil,
+		"trailer to append to commit messages, e.g. \"Signed-off-by: Name <email>\" (can be repeated)",
+	)
+
+	cmd.Flags().StringVar(
+		&opts.commitAuthor,
+		"commit-author",
+		"",
+		"author of commits, as \"Name <email>\" (defaults to the git configuration)",
+	)
+}
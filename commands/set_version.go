@@ -41,6 +41,8 @@ func addSetVersion(topLevel *cobra.Command) {
 		},
 	}
 
+	addCommitFlags(cmd, vo)
+
 	topLevel.AddCommand(cmd)
 }
 
@@ -51,7 +53,20 @@ func runSetVersion(opts *options, args []string) error {
 		return errors.New("expected exactly two arguments: <dependency> <version>")
 	}
 
-	client, err := dependency.NewLocalClient()
+	var clientOpts []dependency.ClientOption
+	if opts.commit {
+		committer, err := opts.committer()
+		if err != nil {
+			return err
+		}
+		clientOpts = append(
+			clientOpts,
+			dependency.WithBeforeUpgrade(committer.BeforeUpgrade),
+			dependency.WithOnUpgrade(committer.OnUpgrade),
+		)
+	}
+
+	client, err := dependency.NewLocalClient(clientOpts...)
 	if err != nil {
 		return err
 	}
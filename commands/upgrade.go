@@ -17,7 +17,9 @@ limitations under the License.
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -40,13 +42,130 @@ func addUpgrade(topLevel *cobra.Command) {
 		},
 	}
 
+	addChangelogFlag(cmd, vo)
+	addCommitFlags(cmd, vo)
+	addPullRequestFlags(cmd, vo)
+
+	cmd.Flags().BoolVar(
+		&vo.combineCommits,
+		"combine-commits",
+		false,
+		"with --commit, commit all upgrades at once instead of one commit per dependency",
+	)
+
 	topLevel.AddCommand(cmd)
 }
 
+func addPullRequestFlags(cmd *cobra.Command, opts *options) {
+	cmd.Flags().BoolVar(
+		&opts.openPR,
+		"open-pr",
+		false,
+		"instead of upgrading in place, open a pull request per update (or group of updates) unless one is already open",
+	)
+
+	cmd.Flags().StringVar(
+		(*string)(&opts.pullRequest.Platform),
+		"pr-platform",
+		string(dependency.PlatformGitHub),
+		"platform to open pull requests on, either github ($GITHUB_TOKEN) or gitlab ($GITLAB_TOKEN)",
+	)
+
+	cmd.Flags().StringVar(
+		&opts.pullRequest.Server,
+		"pr-server",
+		"",
+		"API server of a self-hosted GitHub or GitLab ($GITLAB_PRIVATE_TOKEN)",
+	)
+
+	cmd.Flags().StringVar(
+		&opts.prRepo,
+		"pr-repo",
+		"",
+		"repository to open pull requests on, as owner/repo (defaults to the one of the remote)",
+	)
+
+	cmd.Flags().StringVar(
+		&opts.pullRequest.Remote,
+		"pr-remote",
+		"origin",
+		"git remote to push branches to",
+	)
+
+	cmd.Flags().StringVar(
+		&opts.pullRequest.Git.Base,
+		"pr-base",
+		"",
+		"base branch of pull requests (defaults to the checked out branch)",
+	)
+
+	cmd.Flags().StringVar(
+		&opts.pullRequest.BranchTemplate,
+		"pr-branch",
+		"",
+		"text/template of the branch of pull requests, executed with .Name and .Updates (see the README for the default)",
+	)
+
+	cmd.Flags().StringVar(
+		&opts.pullRequest.TitleTemplate,
+		"pr-title",
+		"",
+		"text/template of the title of pull requests, executed with .Name and .Updates (see the README for the default)",
+	)
+
+	cmd.Flags().StringVar(
+		&opts.pullRequest.BodyTemplate,
+		"pr-body",
+		"",
+		"text/template of the body of pull requests, executed with .Name and .Updates (see the README for the default)",
+	)
+}
+
+// pullRequestOptions returns the pull request options matching the command
+// options.
+func (o *options) pullRequestOptions() (*dependency.PullRequestOptions, error) {
+	opts := o.pullRequest
+
+	if o.prRepo != "" {
+		i := strings.LastIndex(o.prRepo, "/")
+		if i <= 0 || i == len(o.prRepo)-1 {
+			return nil, errors.New("--pr-repo should be in the form owner/repo")
+		}
+		opts.Git.Org, opts.Git.Repo = o.prRepo[:i], o.prRepo[i+1:]
+	}
+
+	var err error
+	if opts.Git.UserName, opts.Git.Email, err = o.author(); err != nil {
+		return nil, err
+	}
+	opts.MessageTemplate = o.commitMessage
+	opts.Trailers = o.commitTrailers
+
+	return &opts, nil
+}
+
 // runUpgrade is the function invoked by 'addUpgrade', responsible for
 // upgrading dependencies.
 func runUpgrade(opts *options) error {
-	client, err := dependency.NewRemoteClient()
+	if opts.openPR && opts.commit {
+		return errors.New("--commit cannot be used with --open-pr, which commits on the branches of pull requests")
+	}
+
+	clientOpts := clientOptions(opts)
+	var committer *dependency.Committer
+	if opts.commit {
+		var err error
+		if committer, err = opts.committer(); err != nil {
+			return err
+		}
+		clientOpts = append(
+			clientOpts,
+			dependency.WithBeforeUpgrade(committer.BeforeUpgrade),
+			dependency.WithOnUpgrade(committer.OnUpgrade),
+		)
+	}
+
+	client, err := dependency.NewRemoteClient(clientOpts...)
 	if err != nil {
 		return err
 	}
@@ -56,9 +175,28 @@ func runUpgrade(opts *options) error {
 		return fmt.Errorf("checking local dependencies: %w", err)
 	}
 
-	updates, err := client.Upgrade(opts.configFile, opts.basePath)
-	if err != nil {
-		return fmt.Errorf("upgrade dependencies: %w", err)
+	var updates []string
+	if opts.openPR {
+		pullRequestOptions, err := opts.pullRequestOptions()
+		if err != nil {
+			return err
+		}
+
+		updates, err = client.OpenPullRequests(opts.configFile, opts.basePath, pullRequestOptions)
+		if err != nil {
+			return fmt.Errorf("open pull requests: %w", err)
+		}
+	} else {
+		updates, err = client.Upgrade(opts.configFile, opts.basePath)
+		if err != nil {
+			return fmt.Errorf("upgrade dependencies: %w", err)
+		}
+
+		if committer != nil {
+			if err := committer.Flush(); err != nil {
+				return fmt.Errorf("commit upgrades: %w", err)
+			}
+		}
 	}
 
 	for _, update := range updates {
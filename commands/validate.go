@@ -17,8 +17,11 @@ limitations under the License.
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/zeitgeist/dependency"
@@ -33,13 +36,27 @@ func addValidate(topLevel *cobra.Command) {
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		PreRunE: func(*cobra.Command, []string) error {
-			return vo.setAndValidate()
+			if err := vo.setAndValidate(); err != nil {
+				return err
+			}
+			if vo.audit {
+				return vo.validateAudit()
+			}
+			return nil
 		},
 		RunE: func(_ *cobra.Command, _ []string) error {
 			return runValidate(vo)
 		},
 	}
 
+	cmd.Flags().BoolVar(
+		&vo.audit,
+		"audit",
+		false,
+		"also check current versions for known vulnerabilities, failing if any is found (see the audit command)",
+	)
+	addAuditFlags(cmd, vo)
+
 	topLevel.AddCommand(cmd)
 }
 
@@ -65,14 +82,45 @@ func runValidate(opts *options) error {
 
 	if !opts.localOnly {
 		updates, err := client.RemoteCheck(opts.configFile)
+		for _, update := range updates {
+			fmt.Println(update)
+		}
+
 		if err != nil {
 			return fmt.Errorf("checking remote dependencies: %w", err)
 		}
+	}
 
-		for _, update := range updates {
-			fmt.Println(update)
+	eolErr := checkEndOfLife(opts)
+
+	if opts.audit {
+		return errors.Join(eolErr, runAudit(opts))
+	}
+
+	return eolErr
+}
+
+// checkEndOfLife warns about dependencies whose release cycle is near its end
+// of life, and fails if any is past its failure threshold.
+func checkEndOfLife(opts *options) error {
+	statuses, err := dependency.CheckEndOfLife(opts.configFile, opts.basePath, opts.localOnly, time.Now())
+	if err != nil {
+		return fmt.Errorf("checking end of life of dependencies: %w", err)
+	}
+
+	failed := 0
+	for _, status := range statuses {
+		if status.Failed {
+			logrus.Error(status)
+			failed++
+		} else {
+			logrus.Warn(status)
 		}
 	}
 
+	if failed > 0 {
+		return fmt.Errorf("found %d dependencies past or near their end of life", failed)
+	}
+
 	return nil
 }
@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/zeitgeist/pkg/osv"
+)
+
+// AuditResult lists the known vulnerabilities of a dependency's current
+// version.
+type AuditResult struct {
+	Name            string          `json:"name"                    yaml:"name"`
+	Version         string          `json:"version"                 yaml:"version"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"         yaml:"vulnerabilities"`
+	// FixedVersion is the lowest version fixing all vulnerabilities, empty
+	// if at least one of them has no known fix
+	FixedVersion string `json:"fixed_version,omitempty" yaml:"fixed_version,omitempty"`
+}
+
+// Vulnerability is a known vulnerability of a dependency.
+type Vulnerability struct {
+	ID      string `json:"id"                      yaml:"id"`
+	Summary string `json:"summary,omitempty"       yaml:"summary,omitempty"`
+	// FixedVersion is the lowest version fixing the vulnerability, if any
+	FixedVersion string `json:"fixed_version,omitempty" yaml:"fixed_version,omitempty"`
+}
+
+func (r AuditResult) String() string {
+	ids := make([]string, 0, len(r.Vulnerabilities))
+	for _, vuln := range r.Vulnerabilities {
+		ids = append(ids, vuln.ID)
+	}
+
+	fix := "no fixed version known"
+	if r.FixedVersion != "" {
+		fix = "fixed in " + r.FixedVersion
+	}
+
+	return fmt.Sprintf(
+		"Dependency %s %s has %d known vulnerabilities (%s), %s",
+		r.Name, r.Version, len(r.Vulnerabilities), strings.Join(ids, ", "), fix,
+	)
+}
+
+// leadingV matches a "v" prefix in front of a version number, which OSV
+// versions do not have.
+var leadingV = regexp.MustCompile(`^v\d`)
+
+// Audit checks the current version of dependencies which have an ecosystem
+// and package against the advisories of the OSV database `db`, and returns
+// the vulnerable ones.
+func Audit(dependencyFilePath string, db osv.Database) ([]AuditResult, error) {
+	externalDeps, err := fromFile(dependencyFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []AuditResult{}
+	for _, dep := range externalDeps.Dependencies {
+		if dep.Ecosystem == "" {
+			log.Debugf("Dependency %s has no ecosystem, skipping audit", dep.Name)
+			continue
+		}
+
+		version, _ := SplitDigest(dep.Version)
+		if leadingV.MatchString(version) {
+			version = version[1:]
+		}
+
+		log.Debugf("Auditing %s package %s %s", dep.Ecosystem, dep.Package, version)
+		vulns, err := db.Query(dep.Ecosystem, dep.Package, version)
+		if err != nil {
+			return nil, fmt.Errorf("auditing dependency %s: %w", dep.Name, err)
+		}
+
+		if len(vulns) == 0 {
+			continue
+		}
+
+		result := AuditResult{Name: dep.Name, Version: dep.Version}
+		fixable := true
+		var highestFix *semver.Version
+		for _, vuln := range vulns {
+			_, fixed := vuln.Affects(dep.Ecosystem, dep.Package, version)
+			result.Vulnerabilities = append(result.Vulnerabilities, Vulnerability{
+				ID:           vuln.ID,
+				Summary:      vuln.Summary,
+				FixedVersion: fixed,
+			})
+
+			fixedVersion, err := semver.ParseTolerant(fixed)
+			if err != nil {
+				fixable = false
+				continue
+			}
+			if highestFix == nil || fixedVersion.GT(*highestFix) {
+				highestFix = &fixedVersion
+				result.FixedVersion = fixed
+			}
+		}
+
+		if !fixable {
+			result.FixedVersion = ""
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
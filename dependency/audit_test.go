@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/zeitgeist/pkg/osv"
+)
+
+// fakeDatabase returns its vulnerabilities for any package version they
+// affect.
+type fakeDatabase struct {
+	vulns []*osv.Vulnerability
+	err   error
+}
+
+func (f *fakeDatabase) Query(ecosystem, name, version string) ([]*osv.Vulnerability, error) {
+	var vulns []*osv.Vulnerability
+	for _, vuln := range f.vulns {
+		if affected, _ := vuln.Affects(ecosystem, name, version); affected {
+			vulns = append(vulns, vuln)
+		}
+	}
+	return vulns, f.err
+}
+
+func cobraVuln(id, introduced, fixed string) *osv.Vulnerability {
+	events := []osv.Event{{Introduced: introduced}}
+	if fixed != "" {
+		events = append(events, osv.Event{Fixed: fixed})
+	}
+
+	return &osv.Vulnerability{
+		ID:      id,
+		Summary: "Bad things in " + id,
+		Affected: []osv.Affected{{
+			Package: osv.Package{Ecosystem: "Go", Name: "github.com/spf13/cobra"},
+			Ranges:  []osv.Range{{Type: "SEMVER", Events: events}},
+		}},
+	}
+}
+
+func TestAudit(t *testing.T) {
+	db := &fakeDatabase{vulns: []*osv.Vulnerability{
+		cobraVuln("GO-1", "0", "1.2.1"),
+		cobraVuln("GO-2", "1.1.0", "1.4.0"),
+		cobraVuln("GO-3", "1.5.0", "1.5.1"),
+	}}
+
+	results, err := Audit("../testdata/local-audit.yaml", db)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "cobra", results[0].Name)
+	require.Equal(t, "v1.2.0", results[0].Version)
+	require.Equal(t, "1.4.0", results[0].FixedVersion)
+	require.Equal(t, []Vulnerability{
+		{ID: "GO-1", Summary: "Bad things in GO-1", FixedVersion: "1.2.1"},
+		{ID: "GO-2", Summary: "Bad things in GO-2", FixedVersion: "1.4.0"},
+	}, results[0].Vulnerabilities)
+	require.Equal(t, "Dependency cobra v1.2.0 has 2 known vulnerabilities (GO-1, GO-2), fixed in 1.4.0", results[0].String())
+
+	// A vulnerability without a fix leaves no fixed version
+	db.vulns = append(db.vulns, cobraVuln("GO-4", "1.0.0", ""))
+	results, err = Audit("../testdata/local-audit.yaml", db)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Len(t, results[0].Vulnerabilities, 3)
+	require.Empty(t, results[0].FixedVersion)
+
+	db.vulns = nil
+	results, err = Audit("../testdata/local-audit.yaml", db)
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	db.err = errors.New("osv is down")
+	_, err = Audit("../testdata/local-audit.yaml", db)
+	require.Error(t, err)
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ReleaseNote is the release notes of a version, in Markdown.
+type ReleaseNote struct {
+	Version string
+	Notes   string
+}
+
+var (
+	// headingRegex matches Markdown headings, capturing their level.
+	headingRegex = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+
+	// breakingRegex matches mentions of breaking changes, e.g. "Breaking
+	// changes", "BREAKING CHANGE:" or "breaking:".
+	breakingRegex = regexp.MustCompile(`(?i)\bbreaking\b`)
+)
+
+// breakingChanges returns the parts of release notes about breaking changes:
+// the content of sections whose heading mentions them, and other lines
+// mentioning them.
+func breakingChanges(notes string) []string {
+	var (
+		breaking     []string
+		sectionLevel int // level of the current breaking section, 0 if none
+	)
+
+	for _, line := range strings.Split(notes, "\n") {
+		line = strings.TrimRight(line, " \r")
+
+		if heading := headingRegex.FindStringSubmatch(line); heading != nil {
+			level := len(heading[1])
+			if sectionLevel > 0 && level > sectionLevel {
+				// Sub-section of a breaking section
+				breaking = append(breaking, line)
+				continue
+			}
+
+			sectionLevel = 0
+			if breakingRegex.MatchString(heading[2]) {
+				sectionLevel = level
+			}
+			continue
+		}
+
+		switch {
+		case sectionLevel > 0:
+			if strings.TrimSpace(line) != "" {
+				breaking = append(breaking, line)
+			}
+		case breakingRegex.MatchString(line):
+			breaking = append(breaking, strings.TrimSpace(line))
+		}
+	}
+
+	return breaking
+}
+
+// RenderChangelog renders the release notes of the versions between current
+// and latest as Markdown, newest first. Breaking changes are highlighted at
+// the top. Returns an empty string if there are no release notes.
+func RenderChangelog(name, current, latest string, notes []ReleaseNote) string {
+	if len(notes) == 0 {
+		return ""
+	}
+
+	var changelog strings.Builder
+	fmt.Fprintf(&changelog, "## %s %s → %s\n", name, current, latest)
+
+	var breaking strings.Builder
+	for _, note := range notes {
+		changes := breakingChanges(note.Notes)
+		if len(changes) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&breaking, "> **%s**\n>\n", note.Version)
+		for _, change := range changes {
+			fmt.Fprintf(&breaking, "> %s\n", change)
+		}
+		breaking.WriteString(">\n")
+	}
+	if breaking.Len() > 0 {
+		changelog.WriteString("\n> [!WARNING]\n> **Breaking changes**\n>\n")
+		changelog.WriteString(strings.TrimSuffix(breaking.String(), ">\n"))
+	}
+
+	for _, note := range notes {
+		fmt.Fprintf(&changelog, "\n### %s\n\n%s\n", note.Version, strings.TrimSpace(demoteHeadings(note.Notes)))
+	}
+
+	return changelog.String()
+}
+
+// demoteHeadings nests the headings of release notes below the version
+// heading, e.g. "## Fixes" becomes "#### Fixes".
+func demoteHeadings(notes string) string {
+	lines := strings.Split(notes, "\n")
+	for i, line := range lines {
+		if heading := headingRegex.FindStringSubmatch(line); heading != nil {
+			level := min(max(len(heading[1])+2, 4), 6)
+			lines[i] = strings.Repeat("#", level) + " " + heading[2]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
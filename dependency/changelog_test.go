@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakingChanges(t *testing.T) {
+	notes := `## Features
+
+- Add honking
+
+## Breaking changes
+
+- Remove the --quack flag
+
+### Migration
+
+Use --honk instead.
+
+## Fixes
+
+- Fix geese
+- BREAKING: rename goose to geese
+`
+
+	require.Equal(t, []string{
+		"- Remove the --quack flag",
+		"### Migration",
+		"Use --honk instead.",
+		"- BREAKING: rename goose to geese",
+	}, breakingChanges(notes))
+
+	require.Empty(t, breakingChanges("- Fix geese"))
+}
+
+func TestRenderChangelog(t *testing.T) {
+	require.Empty(t, RenderChangelog("honk", "v1.0.0", "v1.2.0", nil))
+
+	changelog := RenderChangelog("honk", "v1.0.0", "v1.2.0", []ReleaseNote{
+		{Version: "v1.2.0", Notes: "## Breaking changes\n\n- Remove the --quack flag\n"},
+		{Version: "v1.1.0", Notes: "# Features\n\n- Add honking\n"},
+	})
+
+	require.Equal(t, `## honk v1.0.0 → v1.2.0
+
+> [!WARNING]
+> **Breaking changes**
+>
+> **v1.2.0**
+>
+> - Remove the --quack flag
+
+### v1.2.0
+
+#### Breaking changes
+
+- Remove the --quack flag
+
+### v1.1.0
+
+#### Features
+
+- Add honking
+`, changelog)
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/zeitgeist/pkg/git"
+)
+
+// combinedCommitName names the commit of all upgrades in CommitMessage.
+const combinedCommitName = "dependencies"
+
+// DefaultMessageTemplate bumps a single dependency, or lists the upgrades.
+const DefaultMessageTemplate = `{{if eq (len .Upgrades) 1}}Bump {{.Name}} from {{.Current}} to {{.Latest}}` +
+	`{{else}}Bump {{.Name}}
+
+{{range .Upgrades}}- Bump {{.Name}} from {{.Current}} to {{.Latest}}
+{{end}}{{end}}`
+
+// Upgrade is the upgrade of a dependency applied by a client, see
+// ClientOptions.OnUpgrade.
+type Upgrade struct {
+	Name    string
+	Current string
+	Latest  string
+	// Files changed by the upgrade: its refPaths which contained the current
+	// version, and the dependency file
+	Files []string
+}
+
+// CommitMessage is the data given to commit message templates.
+type CommitMessage struct {
+	// Name of the dependency, or of the group of dependencies
+	Name string
+	// Current and Latest versions, if a single dependency is upgraded
+	Current string
+	Latest  string
+	// Upgrades committed together
+	Upgrades []*Upgrade
+}
+
+// NewCommitMessage returns the data of a commit of upgrades, named after the
+// dependency if there is only one, or else after `group`.
+func NewCommitMessage(group string, upgrades ...*Upgrade) *CommitMessage {
+	if len(upgrades) == 1 {
+		return &CommitMessage{
+			Name:     upgrades[0].Name,
+			Current:  upgrades[0].Current,
+			Latest:   upgrades[0].Latest,
+			Upgrades: upgrades,
+		}
+	}
+	return &CommitMessage{Name: group, Upgrades: upgrades}
+}
+
+// trailerRegex matches git trailers, e.g. "Signed-off-by: Jane <jane@example.com>".
+var trailerRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*: \S`)
+
+// RenderCommitMessage executes a commit message template, or
+// DefaultMessageTemplate if empty, and appends the trailers.
+func RenderCommitMessage(text string, trailers []string, data *CommitMessage) (string, error) {
+	message, err := renderTemplate("message", text, DefaultMessageTemplate, data)
+	if err != nil {
+		return "", err
+	}
+	message = strings.TrimSpace(message)
+
+	if len(trailers) > 0 {
+		for _, trailer := range trailers {
+			if !trailerRegex.MatchString(trailer) {
+				return "", fmt.Errorf("invalid trailer %q, should be in the form \"Token: value\"", trailer)
+			}
+		}
+		message += "\n\n" + strings.Join(trailers, "\n")
+	}
+
+	return message + "\n", nil
+}
+
+// CommitOptions configures the commits of upgrades.
+type CommitOptions struct {
+	// Optional: author of the commits from its UserName and Email, defaults
+	// to the git configuration
+	Author git.Info
+	// Optional: text/template template of the commit message, executed with a
+	// CommitMessage (see RenderCommitMessage)
+	MessageTemplate string
+	// Optional: trailers of the commit message
+	Trailers []string
+	// Combine makes a single commit of all upgrades, see Committer.Flush
+	Combine bool
+}
+
+// Committer commits the files changed by upgrades, as reported to OnUpgrade,
+// and only those.
+type Committer struct {
+	worktree *git.Worktree
+	opts     *CommitOptions
+	// dirty files had changes before any upgrade
+	dirty   []string
+	pending []*Upgrade
+}
+
+// NewCommitter opens the repository containing basePath to commit upgrades.
+//
+// Will return an error if changes are already staged, since they would be
+// committed along with the upgrades.
+func NewCommitter(basePath string, opts *CommitOptions) (*Committer, error) {
+	worktree, err := git.OpenWorktree(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	staged, err := worktree.Staged()
+	if err != nil {
+		return nil, err
+	}
+	if len(staged) > 0 {
+		return nil, fmt.Errorf("the repository has staged changes: %s", strings.Join(staged, ", "))
+	}
+
+	dirty, err := worktree.Changes()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Committer{worktree: worktree, opts: opts, dirty: dirty}, nil
+}
+
+// BeforeUpgrade refuses an upgrade changing files which had changes before
+// any upgrade, since they would be committed along with it. It is meant to be
+// given to WithBeforeUpgrade, so that nothing is written when refused.
+func (c *Committer) BeforeUpgrade(upgrade *Upgrade) error {
+	for _, file := range upgrade.Files {
+		path, err := c.relativePath(file)
+		if err != nil {
+			return err
+		}
+
+		if slices.Contains(c.dirty, path) {
+			return fmt.Errorf("refusing to commit %s, which had uncommitted changes", path)
+		}
+	}
+
+	return nil
+}
+
+// OnUpgrade commits the files changed by an upgrade, or keeps them for Flush
+// if commits are combined. It is meant to be given to WithOnUpgrade.
+func (c *Committer) OnUpgrade(upgrade *Upgrade) error {
+	if c.opts.Combine {
+		c.pending = append(c.pending, upgrade)
+		return nil
+	}
+
+	return c.commit(NewCommitMessage(upgrade.Name, upgrade))
+}
+
+// Flush makes the combined commit of the upgrades kept by OnUpgrade, if any.
+func (c *Committer) Flush() error {
+	if len(c.pending) == 0 {
+		return nil
+	}
+
+	err := c.commit(NewCommitMessage(combinedCommitName, c.pending...))
+	c.pending = nil
+	return err
+}
+
+// commit stages the files changed by the upgrades of data and commits them.
+func (c *Committer) commit(data *CommitMessage) error {
+	var paths []string
+	for _, upgrade := range data.Upgrades {
+		for _, file := range upgrade.Files {
+			path, err := c.relativePath(file)
+			if err != nil {
+				return err
+			}
+
+			if !slices.Contains(paths, path) {
+				paths = append(paths, path)
+			}
+		}
+	}
+
+	message, err := RenderCommitMessage(c.opts.MessageTemplate, c.opts.Trailers, data)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.worktree.Commit(paths, message, &c.opts.Author)
+	return err
+}
+
+// relativePath returns the path of a file relative to the root of the
+// worktree, as expected by git.
+func (c *Committer) relativePath(file string) (string, error) {
+	path, err := filepath.Abs(file)
+	if err != nil {
+		return "", err
+	}
+
+	path, err = filepath.Rel(c.worktree.Root(), path)
+	if err != nil || path == ".." || strings.HasPrefix(path, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside of the repository %s", file, c.worktree.Root())
+	}
+
+	return filepath.ToSlash(path), nil
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/zeitgeist/pkg/git"
+)
+
+func TestRenderCommitMessage(t *testing.T) {
+	tool := &Upgrade{Name: "tool", Current: "1.0.0", Latest: "1.1.0"}
+	other := &Upgrade{Name: "other", Current: "0.1.0", Latest: "0.2.0"}
+
+	message, err := RenderCommitMessage("", nil, NewCommitMessage("dependencies", tool))
+	require.NoError(t, err)
+	require.Equal(t, "Bump tool from 1.0.0 to 1.1.0\n", message)
+
+	message, err = RenderCommitMessage("", nil, NewCommitMessage("dependencies", tool, other))
+	require.NoError(t, err)
+	require.Equal(t, "Bump dependencies\n\n- Bump tool from 1.0.0 to 1.1.0\n- Bump other from 0.1.0 to 0.2.0\n", message)
+
+	message, err = RenderCommitMessage(
+		"chore(deps): {{.Name}} {{.Latest}}",
+		[]string{"Signed-off-by: Honk <honk@example.com>", "Change-Type: patch"},
+		NewCommitMessage("dependencies", tool),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "chore(deps): tool 1.1.0\n\nSigned-off-by: Honk <honk@example.com>\nChange-Type: patch\n", message)
+
+	_, err = RenderCommitMessage("", []string{"not a trailer"}, NewCommitMessage("dependencies", tool))
+	require.Error(t, err)
+
+	_, err = RenderCommitMessage("{{.Honk}}", nil, NewCommitMessage("dependencies", tool))
+	require.Error(t, err)
+}
+
+const commitDependencies = `dependencies:
+  - name: app1
+    version: 0.0.1
+    refPaths:
+    - path: versions.txt
+      match: APP1_VERSION
+  - name: app2
+    version: 0.0.1
+    refPaths:
+    - path: versions.txt
+      match: APP2_VERSION
+    - path: other.txt
+      match: APP2_VERSION
+`
+
+// newCommitRepo creates a repository with committed dependencies, and an
+// unrelated uncommitted change.
+func newCommitRepo(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	_, err := gogit.PlainInit(dir, false)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "dependencies.yaml"), []byte(commitDependencies), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "versions.txt"), []byte("APP1_VERSION: 0.0.1\nAPP2_VERSION: 0.0.1\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.txt"), []byte("APP2_VERSION: 0.0.1\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "unrelated.txt"), []byte("honk\n"), 0o644))
+
+	worktree, err := git.OpenWorktree(dir)
+	require.NoError(t, err)
+	_, err = worktree.Commit(
+		[]string{"dependencies.yaml", "versions.txt", "other.txt", "unrelated.txt"},
+		"Initial commit", &git.Info{UserName: "Honk", Email: "honk@example.com"},
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "unrelated.txt"), []byte("goose\n"), 0o644))
+
+	return dir
+}
+
+// commitFiles returns the messages of the commits of a repository, latest
+// first, along with the files changed by the latest one.
+func commitFiles(t *testing.T, dir string) (messages, files []string) {
+	t.Helper()
+
+	repo, err := gogit.PlainOpen(dir)
+	require.NoError(t, err)
+
+	head, err := repo.Head()
+	require.NoError(t, err)
+	commits, err := repo.Log(&gogit.LogOptions{From: head.Hash()})
+	require.NoError(t, err)
+	require.NoError(t, commits.ForEach(func(commit *object.Commit) error {
+		messages = append(messages, commit.Message)
+		return nil
+	}))
+
+	commit, err := repo.CommitObject(head.Hash())
+	require.NoError(t, err)
+	stats, err := commit.Stats()
+	require.NoError(t, err)
+	for _, stat := range stats {
+		files = append(files, stat.Name)
+	}
+
+	return messages, files
+}
+
+func TestCommitter(t *testing.T) {
+	dir := newCommitRepo(t)
+
+	committer, err := NewCommitter(dir, &CommitOptions{
+		Author:   git.Info{UserName: "Zeitgeist", Email: "zeitgeist@example.com"},
+		Trailers: []string{"Signed-off-by: Zeitgeist <zeitgeist@example.com>"},
+	})
+	require.NoError(t, err)
+
+	client, err := NewLocalClient(WithBeforeUpgrade(committer.BeforeUpgrade), WithOnUpgrade(committer.OnUpgrade))
+	require.NoError(t, err)
+	require.NoError(t, client.SetVersion(filepath.Join(dir, "dependencies.yaml"), dir, "app1", "1.0.0"))
+
+	messages, files := commitFiles(t, dir)
+	require.Equal(t, []string{
+		"Bump app1 from 0.0.1 to 1.0.0\n\nSigned-off-by: Zeitgeist <zeitgeist@example.com>\n",
+		"Initial commit",
+	}, messages)
+	require.ElementsMatch(t, []string{"dependencies.yaml", "versions.txt"}, files)
+
+	// Unrelated changes are left alone
+	worktree, err := git.OpenWorktree(dir)
+	require.NoError(t, err)
+	changes, err := worktree.Changes()
+	require.NoError(t, err)
+	require.Equal(t, []string{"unrelated.txt"}, changes)
+}
+
+func TestCommitterUnchangedRefPath(t *testing.T) {
+	dir := newCommitRepo(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.txt"), []byte("APP2_VERSION: 0.0.2\n"), 0o644))
+
+	var upgrade *Upgrade
+	client, err := NewLocalClient(WithOnUpgrade(func(u *Upgrade) error {
+		upgrade = u
+		return nil
+	}))
+	require.NoError(t, err)
+	require.NoError(t, client.SetVersion(filepath.Join(dir, "dependencies.yaml"), dir, "app2", "1.0.0"))
+
+	// other.txt does not contain the current version, and is not changed
+	require.Equal(t, []string{filepath.Join(dir, "versions.txt"), filepath.Join(dir, "dependencies.yaml")}, upgrade.Files)
+	got, err := os.ReadFile(filepath.Join(dir, "other.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "APP2_VERSION: 0.0.2\n", string(got))
+}
+
+func TestCommitterCombine(t *testing.T) {
+	dir := newCommitRepo(t)
+
+	committer, err := NewCommitter(dir, &CommitOptions{
+		Author:  git.Info{UserName: "Zeitgeist", Email: "zeitgeist@example.com"},
+		Combine: true,
+	})
+	require.NoError(t, err)
+
+	client, err := NewLocalClient(WithBeforeUpgrade(committer.BeforeUpgrade), WithOnUpgrade(committer.OnUpgrade))
+	require.NoError(t, err)
+	require.NoError(t, client.SetVersion(filepath.Join(dir, "dependencies.yaml"), dir, "app1", "1.0.0"))
+	require.NoError(t, client.SetVersion(filepath.Join(dir, "dependencies.yaml"), dir, "app2", "2.0.0"))
+
+	messages, _ := commitFiles(t, dir)
+	require.Equal(t, []string{"Initial commit"}, messages)
+
+	require.NoError(t, committer.Flush())
+	messages, files := commitFiles(t, dir)
+	require.Equal(t, []string{
+		"Bump dependencies\n\n- Bump app1 from 0.0.1 to 1.0.0\n- Bump app2 from 0.0.1 to 2.0.0\n",
+		"Initial commit",
+	}, messages)
+	require.ElementsMatch(t, []string{"dependencies.yaml", "versions.txt", "other.txt"}, files)
+
+	// Nothing is left to commit
+	require.NoError(t, committer.Flush())
+	messages, _ = commitFiles(t, dir)
+	require.Len(t, messages, 2)
+}
+
+func TestCommitterRefusesChanges(t *testing.T) {
+	dir := newCommitRepo(t)
+
+	// Files with uncommitted changes are not committed, and nothing is written
+	dirty := []byte("APP1_VERSION: 0.0.1\nAPP2_VERSION: 0.0.1\nhonk\n")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "versions.txt"), dirty, 0o644))
+	committer, err := NewCommitter(dir, &CommitOptions{})
+	require.NoError(t, err)
+	client, err := NewLocalClient(WithBeforeUpgrade(committer.BeforeUpgrade), WithOnUpgrade(committer.OnUpgrade))
+	require.NoError(t, err)
+	err = client.SetVersion(filepath.Join(dir, "dependencies.yaml"), dir, "app1", "1.0.0")
+	require.EqualError(t, err, "refusing to commit versions.txt, which had uncommitted changes")
+
+	for file, content := range map[string]string{
+		"versions.txt":      string(dirty),
+		"dependencies.yaml": commitDependencies,
+	} {
+		got, err := os.ReadFile(filepath.Join(dir, file))
+		require.NoError(t, err)
+		require.Equal(t, content, string(got))
+	}
+
+	// Staged changes would be committed along with upgrades
+	repo, err := gogit.PlainOpen(dir)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = worktree.Add("unrelated.txt")
+	require.NoError(t, err)
+	_, err = NewCommitter(dir, &CommitOptions{})
+	require.EqualError(t, err, "the repository has staged changes: unrelated.txt")
+}
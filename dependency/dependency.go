@@ -25,8 +25,12 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/blang/semver/v4"
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
 )
@@ -40,7 +44,9 @@ type Client interface {
 
 	// RemoteCheck checks whether dependencies are up to date with upstream
 	//
-	// Will return an error if checking the versions upstream fails.
+	// Will return an error if checking the versions upstream fails, or along
+	// with the updates if a dependency lags further behind upstream than its
+	// maxBehind or maxAge.
 	//
 	// Out-of-date dependencies will be printed out on stdout at the INFO level.
 	RemoteCheck(dependencyFilePath string) ([]string, error)
@@ -54,9 +60,59 @@ type Client interface {
 
 	SetVersion(dependencyFilePath, basePath, dependency, version string) error
 
-	RemoteExport(dependencyFilePath string) ([]VersionUpdate, error)
+	// RemoteExport returns the available updates of dependencies, along
+	// with the dependencies withheld from updating, see Export.
+	RemoteExport(dependencyFilePath string) (*Export, error)
 
 	CheckUpstreamVersions(deps []*Dependency) ([]VersionUpdateInfo, error)
+
+	// OpenPullRequests opens a pull or merge request for each available
+	// update, or group of updates, unless one is already open.
+	//
+	// Will return an error if the repository has uncommitted changes, or if
+	// checking the versions upstream, updating files or opening a pull request
+	// fails.
+	OpenPullRequests(dependencyFilePath, basePath string, opts *PullRequestOptions) ([]string, error)
+}
+
+// ClientOptions are the optional settings of a client.
+type ClientOptions struct {
+	// Changelog collects the release notes of pending updates
+	Changelog bool
+	// BeforeUpgrade is called with each upgrade before any file is written,
+	// e.g. to refuse committing files which already had changes. Nothing is
+	// written if it returns an error
+	BeforeUpgrade func(*Upgrade) error
+	// OnUpgrade is called after each dependency is upgraded, once its files
+	// and the dependency file are written, e.g. to commit them
+	OnUpgrade func(*Upgrade) error
+}
+
+// ClientOption sets an optional setting of a client.
+type ClientOption func(*ClientOptions)
+
+// WithChangelog makes exports and upgrades include the release notes of every
+// version between the current and latest ones, for flavours which know them.
+func WithChangelog() ClientOption {
+	return func(o *ClientOptions) {
+		o.Changelog = true
+	}
+}
+
+// WithBeforeUpgrade makes upgrades and set-version call beforeUpgrade with
+// every upgrade and the files it will change, before writing any of them.
+func WithBeforeUpgrade(beforeUpgrade func(*Upgrade) error) ClientOption {
+	return func(o *ClientOptions) {
+		o.BeforeUpgrade = beforeUpgrade
+	}
+}
+
+// WithOnUpgrade makes upgrades and set-version call onUpgrade after each
+// dependency is upgraded, with the files it changed.
+func WithOnUpgrade(onUpgrade func(*Upgrade) error) ClientOption {
+	return func(o *ClientOptions) {
+		o.OnUpgrade = onUpgrade
+	}
 }
 
 type UnsupportedError struct {
@@ -70,6 +126,8 @@ func (u UnsupportedError) Error() string {
 // Dependencies is used to deserialise the configuration file.
 type Dependencies struct {
 	Dependencies []*Dependency `yaml:"dependencies"`
+	// Optional: version skew rules between dependencies
+	Rules []*Rule `yaml:"rules,omitempty"`
 }
 
 // Dependency is the internal representation of a dependency.
@@ -81,6 +139,34 @@ type Dependency struct {
 	Scheme VersionScheme `yaml:"scheme"`
 	// Optional: sensitivity, to alert e.g. on new major versions
 	Sensitivity VersionSensitivity `yaml:"sensitivity,omitempty"`
+	// Optional: whether pre-releases can be proposed as updates, defaults to
+	// only-if-current-is-prerelease
+	Prereleases PrereleasePolicy `yaml:"prereleases,omitempty"`
+	// Optional: minimum time since a version was published for it to be
+	// proposed as an update, e.g. 7d or 36h (see ParseAge)
+	MinAge string `yaml:"minAge,omitempty"`
+	// Optional: versions which are never proposed as updates, either exact
+	// versions or semver ranges, e.g. 1.2.3 or ">= 1.3.0 < 1.3.2"
+	IgnoreVersions []string `yaml:"ignoreVersions,omitempty"`
+	// Optional: hold the dependency at its current version until a date
+	Hold *Hold `yaml:"hold,omitempty"`
+	// Optional: dependencies of the same group are upgraded in a single pull
+	// request
+	Group string `yaml:"group,omitempty"`
+	// Optional: how far behind upstream the dependency can be before failing
+	// validation, e.g. "2 minors" (see ParseBehind)
+	MaxBehind string `yaml:"maxBehind,omitempty"`
+	// Optional: how long the dependency can stay superseded by a newer
+	// version before failing validation, e.g. 90d (see ParseAge)
+	MaxAge string `yaml:"maxAge,omitempty"`
+	// Optional: OSV ecosystem of the dependency, e.g. Go, PyPI or npm, to
+	// audit its version for known vulnerabilities
+	Ecosystem string `yaml:"ecosystem,omitempty"`
+	// Optional: package name of the dependency in its OSV ecosystem, e.g.
+	// github.com/spf13/cobra
+	Package string `yaml:"package,omitempty"`
+	// Optional: end-of-life tracking of the dependency's release cycle
+	EOL *EndOfLife `yaml:"eol,omitempty"`
 	// Optional: upstream
 	Upstream map[string]string `yaml:"upstream,omitempty"`
 	// List of references to this dependency in local files
@@ -93,6 +179,52 @@ type RefPath struct {
 	Path string `yaml:"path"`
 	// Match expression for the line that should contain the dependency's version. Regexp is supported.
 	Match string `yaml:"match"`
+	// Optional: key of the version to look for, for dependencies with one
+	// version per key, e.g. the region of an AMI (see KeyedVersion)
+	Key string `yaml:"key,omitempty"`
+}
+
+// Hold freezes a dependency at its current version until a date.
+type Hold struct {
+	// Date until which the dependency is held, e.g. 2026-12-01
+	Until string `json:"until" yaml:"until"`
+	// Optional: why the dependency is held
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// Active returns whether the hold is still in effect at `now`, i.e. before
+// the start of its Until date (UTC).
+func (h *Hold) Active(now time.Time) (bool, error) {
+	until, err := time.Parse(time.DateOnly, h.Until)
+	if err != nil {
+		return false, fmt.Errorf("invalid hold date %q, should be in the form 2006-01-02: %w", h.Until, err)
+	}
+
+	return now.Before(until), nil
+}
+
+// String describes the hold, e.g. "held until 2026-12-01: waiting for the
+// migration".
+func (h *Hold) String() string {
+	if h.Reason == "" {
+		return "held until " + h.Until
+	}
+	return fmt.Sprintf("held until %s: %s", h.Until, h.Reason)
+}
+
+// VersionFor returns the version this reference should contain for the
+// given dependency version, i.e. the version for its Key if set.
+func (r *RefPath) VersionFor(version string) (string, error) {
+	if r.Key == "" {
+		return version, nil
+	}
+
+	keyed, ok := KeyedVersion(version, r.Key)
+	if !ok {
+		return "", fmt.Errorf("no version for key %s in %s (%s)", r.Key, version, r.Path)
+	}
+
+	return keyed, nil
 }
 
 // UnmarshalYAML implements custom unmarshalling of Dependency with validation.
@@ -128,11 +260,91 @@ func (decoded *Dependency) UnmarshalYAML(unmarshal func(interface{}) error) erro
 		return fmt.Errorf("unknown version scheme: %s", d.Scheme)
 	}
 
+	switch d.Prereleases {
+	case "", PrereleasesExclude, PrereleasesInclude, PrereleasesIfCurrent:
+		// All good!
+	default:
+		return fmt.Errorf("unknown prereleases policy: %s", d.Prereleases)
+	}
+
+	if _, err := ParseAge(d.MinAge); err != nil {
+		return fmt.Errorf("invalid minAge for dependency %s: %w", d.Name, err)
+	}
+
+	for _, ignored := range d.IgnoreVersions {
+		if ignored == "" {
+			return fmt.Errorf("empty ignored version for dependency %s", d.Name)
+		}
+
+		if strings.ContainsAny(ignored[:1], "<>=!") {
+			if _, err := semver.ParseRange(ignored); err != nil {
+				return fmt.Errorf("invalid ignored range %q for dependency %s: %w", ignored, d.Name, err)
+			}
+		}
+	}
+
+	if d.Hold != nil {
+		if _, err := d.Hold.Active(time.Now()); err != nil {
+			return fmt.Errorf("dependency %s: %w", d.Name, err)
+		}
+	}
+
+	if (d.Ecosystem == "") != (d.Package == "") {
+		return fmt.Errorf("dependency %s should have both an `ecosystem` and a `package`, or neither", d.Name)
+	}
+
+	if _, _, err := ParseBehind(d.MaxBehind); err != nil {
+		return fmt.Errorf("invalid maxBehind for dependency %s: %w", d.Name, err)
+	}
+
+	if _, err := ParseAge(d.MaxAge); err != nil {
+		return fmt.Errorf("invalid maxAge for dependency %s: %w", d.Name, err)
+	}
+
+	if d.EOL != nil {
+		if err := d.EOL.validate(); err != nil {
+			return fmt.Errorf("invalid eol for dependency %s: %w", d.Name, err)
+		}
+	}
+
 	log.Debugf("Deserialised Dependency %s: %#v", d.Name, d)
 
 	return nil
 }
 
+// ageRegex matches ages in days or weeks, which Go durations do not support.
+var ageRegex = regexp.MustCompile(`^(\d+)([dw])$`)
+
+// ParseAge parses an age such as minAge, either in days or weeks (e.g. 7d or
+// 2w), or as a Go duration (e.g. 36h). An empty age is zero.
+func ParseAge(age string) (time.Duration, error) {
+	if age == "" {
+		return 0, nil
+	}
+
+	if matches := ageRegex.FindStringSubmatch(age); matches != nil {
+		count, err := strconv.Atoi(matches[1])
+		if err != nil {
+			return 0, err
+		}
+
+		unit := 24 * time.Hour
+		if matches[2] == "w" {
+			unit *= 7
+		}
+		return time.Duration(count) * unit, nil
+	}
+
+	duration, err := time.ParseDuration(age)
+	if err != nil {
+		return 0, fmt.Errorf("age %q should be in days (7d), weeks (2w) or a duration (36h): %w", age, err)
+	}
+	if duration < 0 {
+		return 0, fmt.Errorf("age %q cannot be negative", age)
+	}
+	return duration, nil
+}
+
 func FromFile(dependencyFilePath string) (*Dependencies, error) {
 	depFile, err := os.ReadFile(dependencyFilePath)
 	if err != nil {
@@ -167,11 +379,18 @@ func ToFile(dependencyFilePath string, dependencies *Dependencies) error {
 	return nil
 }
 
-type LocalClient struct{}
+type LocalClient struct {
+	// Options are the optional settings of the client
+	Options ClientOptions
+}
 
 // NewClient returns all clients that can be used to the validation.
-func NewLocalClient() (Client, error) {
-	return &LocalClient{}, nil
+func NewLocalClient(opts ...ClientOption) (Client, error) {
+	client := &LocalClient{}
+	for _, opt := range opts {
+		opt(&client.Options)
+	}
+	return client, nil
 }
 
 // LocalCheck checks whether dependencies are in-sync locally
@@ -193,6 +412,11 @@ func (c *LocalClient) LocalCheck(dependencyFilePath, basePath string) error {
 
 			log.Debugf("Examining file: %s", filePath)
 
+			version, err := refPath.VersionFor(dep.Version)
+			if err != nil {
+				return err
+			}
+
 			file, err := os.Open(filePath)
 			if err != nil {
 				return err
@@ -213,12 +437,12 @@ func (c *LocalClient) LocalCheck(dependencyFilePath, basePath string) error {
 
 				line := scanner.Text()
 				if matcher.MatchString(line) {
-					if strings.Contains(line, dep.Version) {
+					if strings.Contains(line, version) {
 						log.Debugf(
 							"Line %d matches expected regexp %q and version %q: %s",
 							lineNumber,
 							match,
-							dep.Version,
+							version,
 							line,
 						)
 
@@ -248,6 +472,12 @@ func (c *LocalClient) LocalCheck(dependencyFilePath, basePath string) error {
 		}
 	}
 
+	if err := CheckRules(externalDeps.Rules, externalDeps.Versions()); err != nil {
+		log.Errorf("%s breaks version skew rules: %v", dependencyFilePath, err)
+
+		return errors.New("Dependencies do not satisfy their rules")
+	}
+
 	return nil
 }
 
@@ -260,12 +490,13 @@ func (c *LocalClient) SetVersion(dependencyFilePath, basePath, dependency, versi
 		return err
 	}
 
-	found := false
+	var (
+		upgrade  *Upgrade
+		upgraded map[string]string
+	)
 	for _, dep := range externalDeps.Dependencies {
 		if dep.Name == dependency {
-			found = true
-
-			if err := upgradeDependency(basePath, dep, &VersionUpdateInfo{
+			files, contents, err := upgradeDependency(basePath, dep, &VersionUpdateInfo{
 				Name: dep.Name,
 				Current: Version{
 					Version: dep.Version,
@@ -276,24 +507,46 @@ func (c *LocalClient) SetVersion(dependencyFilePath, basePath, dependency, versi
 					Scheme:  dep.Scheme,
 				},
 				UpdateAvailable: true,
-			}); err != nil {
+			}, map[string]string{})
+			if err != nil {
 				return err
 			}
 
+			upgrade = &Upgrade{
+				Name:    dep.Name,
+				Current: dep.Version,
+				Latest:  version,
+				Files:   append(files, dependencyFilePath),
+			}
+			upgraded = contents
 			dep.Version = version
 		}
 	}
 
-	if !found {
+	if upgrade == nil {
 		return fmt.Errorf("dependency %s not found", dependency)
 	}
 
+	if c.Options.BeforeUpgrade != nil {
+		if err := c.Options.BeforeUpgrade(upgrade); err != nil {
+			return err
+		}
+	}
+
+	if err := writeFiles(upgraded); err != nil {
+		return err
+	}
+
 	// Update the dependencies file to reflect the upgrades
 	err = toFile(dependencyFilePath, externalDeps)
 	if err != nil {
 		return err
 	}
 
+	if c.Options.OnUpgrade != nil {
+		return c.Options.OnUpgrade(upgrade)
+	}
+
 	return nil
 }
 
@@ -305,7 +558,7 @@ func (c *LocalClient) Upgrade(dependencyFilePath, basePath string) ([]string, er
 	return nil, UnsupportedError{"upgrade is not supported by the local client"}
 }
 
-func (c *LocalClient) RemoteExport(dependencyFilePath string) ([]VersionUpdate, error) { //nolint: revive
+func (c *LocalClient) RemoteExport(dependencyFilePath string) (*Export, error) { //nolint: revive
 	return nil, UnsupportedError{"remote export is not supported by the local client"}
 }
 
@@ -313,62 +566,103 @@ func (c *LocalClient) CheckUpstreamVersions(deps []*Dependency) ([]VersionUpdate
 	return nil, UnsupportedError{"CheckUpstreamVersions is not supported by the local client"}
 }
 
-var NewRemoteClient = func() (Client, error) {
+func (c *LocalClient) OpenPullRequests(dependencyFilePath, basePath string, opts *PullRequestOptions) ([]string, error) { //nolint: revive
+	return nil, UnsupportedError{"opening pull requests is not supported by the local client"}
+}
+
+var NewRemoteClient = func(...ClientOption) (Client, error) {
 	return nil, UnsupportedError{"remote upstream functionality is not supported by this command; use sigs.k8s.io/zeitgeist/remote/zeitgeist"}
 }
 
-func upgradeDependency(basePath string, dependency *Dependency, versionUpdate *VersionUpdateInfo) error {
+// upgradeDependency replaces the current version with the latest one in the
+// refPaths of the dependency, without writing them. Files are read from
+// `contents` if already there, e.g. upgraded by another dependency, and
+// stored into it. Returns the files which changed, along with their
+// upgraded contents.
+func upgradeDependency(
+	basePath string, dependency *Dependency, versionUpdate *VersionUpdateInfo, contents map[string]string,
+) ([]string, map[string]string, error) {
 	log.Debugf("running upgradeDependency, versionUpdate %#v", versionUpdate)
+	var files []string
+	upgraded := map[string]string{}
 	for _, refPath := range dependency.RefPaths {
-		err := replaceInFile(basePath, refPath, versionUpdate)
+		filename := filepath.Join(basePath, refPath.Path)
+		content, ok := contents[filename]
+		if !ok {
+			inputFile, err := os.ReadFile(filename)
+			if err != nil {
+				return nil, nil, fmt.Errorf("reading file: %w", err)
+			}
+			content = string(inputFile)
+		}
+
+		upgradedContent, err := replaceInContent(content, refPath, versionUpdate)
 		if err != nil {
-			return err
+			return nil, nil, err
+		}
+		contents[filename] = upgradedContent
+
+		if upgradedContent != content {
+			upgraded[filename] = upgradedContent
+			if !slices.Contains(files, filename) {
+				files = append(files, filename)
+			}
 		}
 	}
 
-	return nil
+	return files, upgraded, nil
 }
 
-func replaceInFile(basePath string, refPath *RefPath, versionUpdate *VersionUpdateInfo) error {
-	filename := filepath.Join(basePath, refPath.Path)
-	log.Debugf("running replaceInFile, refpath is %#v, versionUpdate %#v", refPath, versionUpdate)
+// replaceInContent replaces the current version with the latest one in the
+// lines of the content of a refPath.
+func replaceInContent(content string, refPath *RefPath, versionUpdate *VersionUpdateInfo) (string, error) {
+	log.Debugf("running replaceInContent, refpath is %#v, versionUpdate %#v", refPath, versionUpdate)
 
 	matcher, err := regexp.Compile(refPath.Match)
 	if err != nil {
-		return fmt.Errorf("compiling regex: %w", err)
+		return "", fmt.Errorf("compiling regex: %w", err)
 	}
 
-	inputFile, err := os.ReadFile(filename)
+	currentVersion, err := refPath.VersionFor(versionUpdate.Current.Version)
 	if err != nil {
-		return fmt.Errorf("reading file: %w", err)
+		return "", err
 	}
 
-	lines := strings.Split(string(inputFile), "\n")
+	latestVersion, err := refPath.VersionFor(versionUpdate.Latest.Version)
+	if err != nil {
+		return "", err
+	}
+
+	lines := strings.Split(content, "\n")
 
 	for i, line := range lines {
 		if matcher.MatchString(line) {
-			if strings.Contains(line, versionUpdate.Current.Version) {
+			if strings.Contains(line, currentVersion) {
 				log.Debugf(
 					"Line %d matches expected regexp %q and version %q: %s",
 					i,
 					refPath.Match,
-					versionUpdate.Current.Version,
+					currentVersion,
 					line,
 				)
 
 				// The actual upgrade:
-				lines[i] = strings.ReplaceAll(line, versionUpdate.Current.Version, versionUpdate.Latest.Version)
+				lines[i] = strings.ReplaceAll(line, currentVersion, latestVersion)
 			}
 		}
 	}
 
-	upgradedFile := strings.Join(lines, "\n")
+	return strings.Join(lines, "\n"), nil
+}
 
-	// Finally, write the file out
-	err = os.WriteFile(filename, []byte(upgradedFile), 0o644)
-	if err != nil {
-		return fmt.Errorf("writing file: %w", err)
+// writeFiles writes out the upgraded contents of files.
+func writeFiles(contents map[string]string) error {
+	for filename, content := range contents {
+		if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+			return fmt.Errorf("writing file: %w", err)
+		}
 	}
+
 	return nil
 }
 
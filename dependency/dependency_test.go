@@ -20,6 +20,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
@@ -91,6 +92,15 @@ func TestDeserialising(t *testing.T) {
 		"name:",
 		"name: test",
 		"version: 1.0.0",
+		"name: test\nversion: 1.0.0\nprereleases: sometimes",
+		"name: test\nversion: 1.0.0\nminAge: 7 days",
+		"name: test\nversion: 1.0.0\nignoreVersions: ['>= not-a-version']",
+		"name: test\nversion: 1.0.0\nhold:\n  until: next week",
+		"name: test\nversion: 1.0.0\necosystem: Go",
+		"name: test\nversion: 1.0.0\nmaxBehind: 2",
+		"name: test\nversion: 1.0.0\nmaxAge: a while",
+		"name: test\nversion: 1.0.0\neol:\n  cycle: '1.0'",
+		"name: test\nversion: 1.0.0\neol:\n  product: test\n  warnBefore: soon",
 	}
 
 	for _, invalid := range invalidYamls {
@@ -103,6 +113,12 @@ func TestDeserialising(t *testing.T) {
 	validYamls := []string{
 		"name: test\nversion: 1.0.0",
 		"name: test\nversion: 100",
+		"name: test\nversion: 1.0.0-rc.1\nprereleases: only-if-current-is-prerelease",
+		"name: test\nversion: 1.0.0\nminAge: 7d",
+		"name: test\nversion: 1.0.0\nignoreVersions: [1.0.1, '>= 1.1.0 < 1.1.2']\nhold:\n  until: 2026-12-01\n  reason: migration",
+		"name: test\nversion: 1.0.0\necosystem: PyPI\npackage: test",
+		"name: test\nversion: 1.0.0\nmaxBehind: 2 minors\nmaxAge: 90d",
+		"name: test\nversion: 1.0.0\neol:\n  product: test\n  warnBefore: 30d\n  failBefore: 7d",
 	}
 
 	for _, valid := range validYamls {
@@ -151,3 +167,75 @@ dependencies:
 	require.NoError(t, err)
 	require.Equal(t, "APP1_VERSION: 2.1.0\nAPP2_VERSION: 0.0.1", string(got))
 }
+
+func TestLocalKeyedVersions(t *testing.T) {
+	dir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(dir, "amis.tf"), []byte("ami_us_east_1 = \"ami-0123\"\nami_eu_west_1 = \"ami-4567\"\n"), 0o644)
+	require.NoError(t, err)
+
+	writeDependencies := func(version string) {
+		err := os.WriteFile(filepath.Join(dir, "dependencies.yaml"), []byte(`
+dependencies:
+  - name: ami
+    version: `+version+`
+    scheme: random
+    refPaths:
+    - path: amis.tf
+      match: ami_us_east_1
+      key: us-east-1
+    - path: amis.tf
+      match: ami_eu_west_1
+      key: eu-west-1
+`), 0o644)
+		require.NoError(t, err)
+	}
+
+	client, err := NewLocalClient()
+	require.NoError(t, err)
+
+	writeDependencies("us-east-1=ami-0123,eu-west-1=ami-4567")
+	require.NoError(t, client.LocalCheck(filepath.Join(dir, "dependencies.yaml"), dir))
+
+	writeDependencies("us-east-1=ami-4567,eu-west-1=ami-0123")
+	require.Error(t, client.LocalCheck(filepath.Join(dir, "dependencies.yaml"), dir))
+
+	// Missing key
+	writeDependencies("us-east-1=ami-0123")
+	require.Error(t, client.LocalCheck(filepath.Join(dir, "dependencies.yaml"), dir))
+}
+
+func TestParseAge(t *testing.T) {
+	for age, expected := range map[string]time.Duration{
+		"":      0,
+		"7d":    7 * 24 * time.Hour,
+		"2w":    14 * 24 * time.Hour,
+		"36h":   36 * time.Hour,
+		"1h30m": 90 * time.Minute,
+	} {
+		duration, err := ParseAge(age)
+		require.NoError(t, err, age)
+		require.Equal(t, expected, duration, age)
+	}
+
+	for _, invalid := range []string{"7 days", "d", "1.5d", "-1h"} {
+		_, err := ParseAge(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestHoldActive(t *testing.T) {
+	hold := &Hold{Until: "2026-12-01", Reason: "migration"}
+	require.Equal(t, "held until 2026-12-01: migration", hold.String())
+
+	active, err := hold.Active(time.Date(2026, time.November, 30, 23, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.True(t, active)
+
+	active, err = hold.Active(time.Date(2026, time.December, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.False(t, active)
+
+	_, err = (&Hold{Until: "December"}).Active(time.Now())
+	require.Error(t, err)
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/zeitgeist/pkg/endoflife"
+)
+
+const (
+	// defaultEOLWarning is how long before the end of life of a cycle to
+	// start warning about it.
+	defaultEOLWarning = 90 * 24 * time.Hour
+	// eksProduct is the name of EKS on endoflife.date, whose end of life is
+	// the end of standard support.
+	eksProduct = "amazon-eks"
+)
+
+// EndOfLife configures the end-of-life tracking of a dependency, using
+// release cycles from endoflife.date or a local file in the same format.
+type EndOfLife struct {
+	// Product on endoflife.date, e.g. kubernetes or nodejs
+	Product string `yaml:"product"`
+	// Optional: release cycle of the dependency, defaults to the cycle
+	// matching its version, e.g. 1.29 for 1.29.3
+	Cycle string `yaml:"cycle,omitempty"`
+	// Optional: local JSON file holding the release cycles, relative to the
+	// base path, instead of the endoflife.date API
+	File string `yaml:"file,omitempty"`
+	// Optional: endoflife.date API server, defaults to https://endoflife.date
+	Server string `yaml:"server,omitempty"`
+	// Optional: warn when the end of life is closer than this, defaults to
+	// 90d (see ParseAge)
+	WarnBefore string `yaml:"warnBefore,omitempty"`
+	// Optional: fail when the end of life is closer than this, defaults to
+	// failing once the end of life is reached
+	FailBefore string `yaml:"failBefore,omitempty"`
+}
+
+func (e *EndOfLife) validate() error {
+	if e.Product == "" {
+		return errors.New("no `product`")
+	}
+
+	if _, err := ParseAge(e.WarnBefore); err != nil {
+		return fmt.Errorf("warnBefore: %w", err)
+	}
+
+	if _, err := ParseAge(e.FailBefore); err != nil {
+		return fmt.Errorf("failBefore: %w", err)
+	}
+
+	return nil
+}
+
+// EndOfLifeStatus is a dependency whose release cycle is past or near its
+// end of life.
+type EndOfLifeStatus struct {
+	Name    string
+	Version string
+	Product string
+	Cycle   string
+	// EOL is the end-of-life date, zero if the cycle ended without a date
+	EOL time.Time
+	// Reached is set if the end of life has been reached
+	Reached bool
+	// Failed is set if the end of life is within FailBefore, or reached by
+	// default
+	Failed bool
+}
+
+func (s EndOfLifeStatus) String() string {
+	switch {
+	case s.EOL.IsZero():
+		return fmt.Sprintf("Dependency %s %s: %s %s has reached its end of life", s.Name, s.Version, s.Product, s.Cycle)
+	case s.Reached:
+		return fmt.Sprintf(
+			"Dependency %s %s: %s %s reached its end of life on %s",
+			s.Name, s.Version, s.Product, s.Cycle, s.EOL.Format(time.DateOnly),
+		)
+	default:
+		return fmt.Sprintf(
+			"Dependency %s %s: %s %s reaches its end of life on %s",
+			s.Name, s.Version, s.Product, s.Cycle, s.EOL.Format(time.DateOnly),
+		)
+	}
+}
+
+// CheckEndOfLife returns the dependencies with end-of-life tracking whose
+// release cycle is past or near its end of life at `now`.
+//
+// If offline is set, only dependencies whose cycles come from a local file
+// are checked.
+func CheckEndOfLife(dependencyFilePath, basePath string, offline bool, now time.Time) ([]EndOfLifeStatus, error) {
+	externalDeps, err := fromFile(dependencyFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []EndOfLifeStatus{}
+	for _, dep := range externalDeps.Dependencies {
+		eol := dep.EOL
+		if eol == nil {
+			eol = eksEndOfLife(dep)
+		}
+		if eol == nil {
+			continue
+		}
+
+		if offline && eol.File == "" {
+			log.Debugf("Dependency %s has no local eol file, skipping end-of-life check", dep.Name)
+			continue
+		}
+
+		status, err := checkEndOfLife(dep, eol, basePath, now)
+		if err != nil {
+			return nil, fmt.Errorf("checking end of life of dependency %s: %w", dep.Name, err)
+		}
+		if status != nil {
+			statuses = append(statuses, *status)
+		}
+	}
+
+	return statuses, nil
+}
+
+// eksEndOfLife returns the end-of-life tracking of dependencies with an eks
+// upstream and no `eol` block, i.e. the end of standard support of their
+// Kubernetes version as listed by endoflife.date, or nil for other
+// dependencies.
+func eksEndOfLife(dep *Dependency) *EndOfLife {
+	if dep.Upstream["flavour"] != "eks" {
+		return nil
+	}
+
+	eol := &EndOfLife{Product: eksProduct, Server: dep.Upstream["server"]}
+	if dep.Upstream["track"] == "platform" {
+		eol.Cycle = dep.Upstream["kubernetesVersion"]
+	}
+
+	return eol
+}
+
+func checkEndOfLife(dep *Dependency, eol *EndOfLife, basePath string, now time.Time) (*EndOfLifeStatus, error) {
+	var (
+		cycles []*endoflife.Cycle
+		err    error
+	)
+	if eol.File != "" {
+		cycles, err = endoflife.FromFile(filepath.Join(basePath, eol.File))
+	} else {
+		cycles, err = endoflife.New(eol.Server).Cycles(eol.Product)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	version, _ := SplitDigest(dep.Version)
+	if eol.Cycle != "" {
+		version = eol.Cycle
+	}
+
+	cycle := endoflife.MatchCycle(cycles, version)
+	if cycle == nil {
+		return nil, fmt.Errorf("no %s release cycle found for version %s", eol.Product, version)
+	}
+
+	warnBefore := defaultEOLWarning
+	if eol.WarnBefore != "" {
+		// Already validated when deserialising
+		warnBefore, _ = ParseAge(eol.WarnBefore) //nolint:errcheck
+	}
+	failBefore, _ := ParseAge(eol.FailBefore) //nolint:errcheck
+
+	status := &EndOfLifeStatus{
+		Name:    dep.Name,
+		Version: dep.Version,
+		Product: eol.Product,
+		Cycle:   cycle.Cycle,
+		EOL:     cycle.EOL,
+	}
+
+	switch {
+	case cycle.EOL.IsZero():
+		if !cycle.Ended {
+			return nil, nil
+		}
+		status.Reached = true
+		status.Failed = true
+	case now.Add(failBefore).Before(cycle.EOL) && now.Add(warnBefore).Before(cycle.EOL):
+		log.Debugf("Dependency %s: %s %s reaches its end of life on %s", dep.Name, eol.Product, cycle.Cycle, cycle.EOL.Format(time.DateOnly))
+		return nil, nil
+	default:
+		status.Reached = !now.Before(cycle.EOL)
+		status.Failed = !now.Add(failBefore).Before(cycle.EOL)
+	}
+
+	return status, nil
+}
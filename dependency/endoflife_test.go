@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckEndOfLife(t *testing.T) {
+	eol := func(date string) time.Time {
+		d, err := time.Parse(time.DateOnly, date)
+		require.NoError(t, err)
+		return d
+	}
+
+	// Long before any end of life
+	statuses, err := CheckEndOfLife("../testdata/local-eol.yaml", "../testdata", true, eol("2024-01-01"))
+	require.NoError(t, err)
+	require.Empty(t, statuses)
+
+	// kind-node within its failBefore, kubectl not yet within its warnBefore
+	statuses, err = CheckEndOfLife("../testdata/local-eol.yaml", "../testdata", true, eol("2025-02-10"))
+	require.NoError(t, err)
+	require.Equal(t, []EndOfLifeStatus{
+		{Name: "kind-node", Version: "1.29.10", Product: "kubernetes", Cycle: "1.29", EOL: eol("2025-02-28"), Failed: true},
+	}, statuses)
+
+	// kubernetes within the default warnBefore, kind-node past its end of life
+	statuses, err = CheckEndOfLife("../testdata/local-eol.yaml", "../testdata", true, eol("2025-04-01"))
+	require.NoError(t, err)
+	require.Equal(t, []EndOfLifeStatus{
+		{Name: "kubernetes", Version: "1.30.6", Product: "kubernetes", Cycle: "1.30", EOL: eol("2025-06-28")},
+		{Name: "kind-node", Version: "1.29.10", Product: "kubernetes", Cycle: "1.29", EOL: eol("2025-02-28"), Reached: true, Failed: true},
+	}, statuses)
+	require.Equal(t, "Dependency kubernetes 1.30.6: kubernetes 1.30 reaches its end of life on 2025-06-28", statuses[0].String())
+
+	// Everything past its end of life
+	statuses, err = CheckEndOfLife("../testdata/local-eol.yaml", "../testdata", true, eol("2026-01-01"))
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	for _, status := range statuses {
+		require.True(t, status.Reached)
+		require.True(t, status.Failed)
+	}
+	require.Equal(t, "Dependency kubectl v1.31.2: kubernetes 1.31 reached its end of life on 2025-10-28", statuses[1].String())
+
+	_, err = CheckEndOfLife("../testdata/does-not-exist.yaml", "../testdata", true, time.Now())
+	require.Error(t, err)
+}
+
+func TestEndOfLifeUnknownCycle(t *testing.T) {
+	dep := &Dependency{
+		Name:    "kubernetes",
+		Version: "1.2.7",
+		EOL:     &EndOfLife{Product: "kubernetes", File: "eol/kubernetes.json"},
+	}
+
+	status, err := checkEndOfLife(dep, dep.EOL, "../testdata", time.Now())
+	require.NoError(t, err)
+	require.Equal(t, "Dependency kubernetes 1.2.7: kubernetes 1.2 has reached its end of life", status.String())
+
+	dep.Version = "0.1.0"
+	_, err = checkEndOfLife(dep, dep.EOL, "../testdata", time.Now())
+	require.Error(t, err)
+}
+
+func TestEndOfLifeEKS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/amazon-eks.json" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(rw, `[
+			{"cycle": "1.31", "eol": "2025-11-26", "extendedSupport": "2026-11-26", "latest": "1.31-eks-12"},
+			{"cycle": "1.29", "eol": "2025-03-23", "extendedSupport": "2026-03-23", "latest": "1.29-eks-25"}
+		]`)
+	}))
+	defer server.Close()
+
+	// Dependencies with an eks upstream are tracked without an eol block
+	dependencyFile := filepath.Join(t.TempDir(), "dependencies.yaml")
+	require.NoError(t, os.WriteFile(dependencyFile, []byte(fmt.Sprintf(`dependencies:
+- name: eks
+  version: "1.29"
+  upstream:
+    flavour: eks
+    server: %[1]s
+- name: eks-platform
+  version: eks.12
+  scheme: random
+  upstream:
+    flavour: eks
+    server: %[1]s
+    track: platform
+    kubernetesVersion: "1.31"
+- name: eks-explicit
+  version: "1.31"
+  upstream:
+    flavour: eks
+  eol:
+    product: amazon-eks
+    server: %[1]s
+    warnBefore: 1d
+`, server.URL)), 0o644))
+
+	now := time.Date(2025, time.October, 1, 0, 0, 0, 0, time.UTC)
+	statuses, err := CheckEndOfLife(dependencyFile, "", false, now)
+	require.NoError(t, err)
+	require.Equal(t, []EndOfLifeStatus{
+		{
+			Name: "eks", Version: "1.29", Product: "amazon-eks", Cycle: "1.29",
+			EOL: time.Date(2025, time.March, 23, 0, 0, 0, 0, time.UTC), Reached: true, Failed: true,
+		},
+		{
+			Name: "eks-platform", Version: "eks.12", Product: "amazon-eks", Cycle: "1.31",
+			EOL: time.Date(2025, time.November, 26, 0, 0, 0, 0, time.UTC),
+		},
+	}, statuses)
+
+	// Without a local file, nothing is checked offline
+	statuses, err = CheckEndOfLife(dependencyFile, "", true, now)
+	require.NoError(t, err)
+	require.Empty(t, statuses)
+}
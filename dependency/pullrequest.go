@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"sigs.k8s.io/zeitgeist/pkg/git"
+)
+
+// Platform hosts repositories and their pull or merge requests.
+type Platform string
+
+const (
+	// PlatformGitHub opens pull requests on GitHub.
+	PlatformGitHub Platform = "github"
+	// PlatformGitLab opens merge requests on GitLab.
+	PlatformGitLab Platform = "gitlab"
+)
+
+const (
+	// DefaultBranchTemplate names branches after the dependency, or group,
+	// only, so that the open pull request of an update is found again once a
+	// newer version is released.
+	DefaultBranchTemplate = `zeitgeist/{{.Name}}`
+	// DefaultTitleTemplate describes the upgrade of a dependency, or names the
+	// group.
+	DefaultTitleTemplate = `{{if eq (len .Updates) 1}}{{with index .Updates 0}}` +
+		`Upgrade {{.Name}} from {{.Version}} to {{.NewVersion}}{{end}}` +
+		`{{else}}Upgrade {{.Name}}{{end}}`
+	// DefaultBodyTemplate lists the upgrades, followed by their changelogs.
+	DefaultBodyTemplate = `{{range .Updates}}- Upgrade {{.Name}} from {{.Version}} to {{.NewVersion}}
+{{end}}{{range .Updates}}{{with .Changelog}}
+{{.}}{{end}}{{end}}`
+)
+
+// Proposal is a set of updates proposed together, e.g. in a pull request.
+// It is the data given to the branch, title and body templates of
+// PullRequestOptions.
+type Proposal struct {
+	// Name of the dependency, or of the group of dependencies
+	Name string
+	// Updates proposed together
+	Updates []VersionUpdate
+}
+
+// Proposals groups updates into proposals: one per dependency, or one per
+// group for dependencies with a group, in the order of the updates.
+func Proposals(dependencies []*Dependency, updates []VersionUpdate) []Proposal {
+	groups := make(map[string]string, len(dependencies))
+	for _, dep := range dependencies {
+		groups[dep.Name] = dep.Group
+	}
+
+	var proposals []Proposal
+	index := map[string]int{}
+	for _, update := range updates {
+		name := update.Name
+		if group := groups[update.Name]; group != "" {
+			name = group
+		}
+
+		i, ok := index[name]
+		if !ok {
+			i = len(proposals)
+			index[name] = i
+			proposals = append(proposals, Proposal{Name: name})
+		}
+		proposals[i].Updates = append(proposals[i].Updates, update)
+	}
+
+	return proposals
+}
+
+// PullRequestOptions configures the pull or merge requests opened for
+// updates.
+type PullRequestOptions struct {
+	// Repository, base branch and commit identity. Org and Repo default to
+	// those of the remote URL, Base to the checked out branch and UserID to
+	// Org; Head is set for each pull request from BranchTemplate.
+	Git git.Info
+	// Platform hosting the repository, defaults to github
+	Platform Platform
+	// Optional: API server of a self-hosted platform
+	Server string
+	// Optional: git remote to push branches to, defaults to origin
+	Remote string
+	// Optional: text/template templates of the branch, title and body,
+	// executed with a Proposal
+	BranchTemplate string
+	TitleTemplate  string
+	BodyTemplate   string
+	// Optional: text/template template of the commit message, executed with
+	// a CommitMessage (see RenderCommitMessage)
+	MessageTemplate string
+	// Optional: trailers of the commit message, e.g. "Signed-off-by: Jane
+	// <jane@example.com>"
+	Trailers []string
+}
+
+// invalidBranchRegex matches characters which are not allowed, or not
+// welcome, in branch names.
+var invalidBranchRegex = regexp.MustCompile(`[^A-Za-z0-9._/-]+`)
+
+// Render executes the templates of the pull request for a proposal,
+// returning its branch, title, body and commit message.
+func (o *PullRequestOptions) Render(proposal *Proposal) (branch, title, body, message string, err error) {
+	if branch, err = renderTemplate("branch", o.BranchTemplate, DefaultBranchTemplate, proposal); err != nil {
+		return "", "", "", "", err
+	}
+	branch = strings.Trim(invalidBranchRegex.ReplaceAllString(branch, "-"), "-./")
+
+	if title, err = renderTemplate("title", o.TitleTemplate, DefaultTitleTemplate, proposal); err != nil {
+		return "", "", "", "", err
+	}
+
+	if body, err = renderTemplate("body", o.BodyTemplate, DefaultBodyTemplate, proposal); err != nil {
+		return "", "", "", "", err
+	}
+
+	upgrades := make([]*Upgrade, 0, len(proposal.Updates))
+	for _, update := range proposal.Updates {
+		upgrades = append(upgrades, &Upgrade{Name: update.Name, Current: update.Version, Latest: update.NewVersion})
+	}
+	if message, err = RenderCommitMessage(o.MessageTemplate, o.Trailers, NewCommitMessage(proposal.Name, upgrades...)); err != nil {
+		return "", "", "", "", err
+	}
+
+	return branch, strings.TrimSpace(title), body, message, nil
+}
+
+// renderTemplate executes a template, or its default if empty, with data.
+func renderTemplate(name, text, defaultText string, data any) (string, error) {
+	if text == "" {
+		text = defaultText
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("parsing %s template: %w", name, err)
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("rendering %s template: %w", name, err)
+	}
+
+	return rendered.String(), nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProposals(t *testing.T) {
+	dependencies := []*Dependency{
+		{Name: "eks"},
+		{Name: "terraform"},
+		{Name: "kubectl", Group: "kubernetes"},
+		{Name: "kubernetes", Group: "kubernetes"},
+	}
+	updates := []VersionUpdate{
+		{Name: "kubectl", Version: "1.30.0", NewVersion: "1.31.0"},
+		{Name: "terraform", Version: "1.9.0", NewVersion: "1.10.0"},
+		{Name: "kubernetes", Version: "1.30.0", NewVersion: "1.31.0"},
+	}
+
+	require.Equal(t, []Proposal{
+		{Name: "kubernetes", Updates: []VersionUpdate{updates[0], updates[2]}},
+		{Name: "terraform", Updates: []VersionUpdate{updates[1]}},
+	}, Proposals(dependencies, updates))
+}
+
+func TestPullRequestRender(t *testing.T) {
+	single := &Proposal{Name: "honk", Updates: []VersionUpdate{
+		{Name: "honk", Version: "1.0.0", NewVersion: "1.1.0@sha256:0123", Changelog: "## honk 1.0.0 → 1.1.0\n"},
+	}}
+
+	branch, title, body, message, err := (&PullRequestOptions{}).Render(single)
+	require.NoError(t, err)
+	require.Equal(t, "zeitgeist/honk", branch)
+	require.Equal(t, "Upgrade honk from 1.0.0 to 1.1.0@sha256:0123", title)
+	require.Equal(t, "- Upgrade honk from 1.0.0 to 1.1.0@sha256:0123\n\n## honk 1.0.0 → 1.1.0\n", body)
+	require.Equal(t, "Bump honk from 1.0.0 to 1.1.0@sha256:0123\n", message)
+
+	// Invalid characters of branch names are replaced
+	branch, _, _, _, err = (&PullRequestOptions{BranchTemplate: "zeitgeist/{{.Name}}{{range .Updates}}-{{.NewVersion}}{{end}}"}).Render(single)
+	require.NoError(t, err)
+	require.Equal(t, "zeitgeist/honk-1.1.0-sha256-0123", branch)
+
+	group := &Proposal{Name: "geese", Updates: []VersionUpdate{
+		{Name: "honk", Version: "1.0.0", NewVersion: "1.1.0"},
+		{Name: "quack", Version: "2.0.0", NewVersion: "3.0.0"},
+	}}
+
+	branch, title, _, message, err = (&PullRequestOptions{
+		BranchTemplate: "deps/{{.Name}}",
+		TitleTemplate:  "chore: bump {{len .Updates}} {{.Name}}",
+		Trailers:       []string{"Signed-off-by: Goose <goose@example.com>"},
+	}).Render(group)
+	require.NoError(t, err)
+	require.Equal(t, "deps/geese", branch)
+	require.Equal(t, "chore: bump 2 geese", title)
+	require.Equal(t, `Bump geese
+
+- Bump honk from 1.0.0 to 1.1.0
+- Bump quack from 2.0.0 to 3.0.0
+
+Signed-off-by: Goose <goose@example.com>
+`, message)
+
+	_, _, _, _, err = (&PullRequestOptions{TitleTemplate: "{{.Missing}}"}).Render(group)
+	require.Error(t, err)
+
+	_, _, _, _, err = (&PullRequestOptions{BodyTemplate: "{{"}).Render(group)
+	require.Error(t, err)
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+)
+
+// SkewDirection restricts in which direction a dependency may drift from
+// another one.
+type SkewDirection string
+
+const (
+	// SkewOlder allows the dependency to be older than the other one, but
+	// never newer.
+	SkewOlder SkewDirection = "older"
+	// SkewNewer allows the dependency to be newer than the other one, but
+	// never older.
+	SkewNewer SkewDirection = "newer"
+)
+
+// Rule is a version skew constraint between two dependencies, e.g. kubectl
+// within one minor version of the Kubernetes control plane.
+type Rule struct {
+	// Optional: name of the rule, used in error messages
+	Name string `yaml:"name,omitempty"`
+	// Dependency constrained by the rule
+	Dependency string `yaml:"dependency"`
+	// Dependency it is compared with
+	RelativeTo string `yaml:"relativeTo"`
+	// Level at which versions are compared, either major or minor. At the
+	// minor level, major versions must be equal.
+	Level VersionSensitivity `yaml:"level"`
+	// Optional: maximum difference at that level, defaults to 0, i.e. equal
+	MaxSkew int `yaml:"maxSkew,omitempty"`
+	// Optional: direction in which the dependency may differ, either older
+	// or newer, defaults to both
+	Direction SkewDirection `yaml:"direction,omitempty"`
+}
+
+// UnmarshalYAML implements custom unmarshalling of Rule with validation.
+func (decoded *Rule) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	// Use a different type to prevent infinite loop in unmarshalling
+	type RuleYAML Rule
+
+	r := (*RuleYAML)(decoded)
+
+	if err := unmarshal(&r); err != nil {
+		return err
+	}
+
+	if r.Dependency == "" || r.RelativeTo == "" {
+		return fmt.Errorf("rule should have a `dependency` and a `relativeTo`: %#v", r)
+	}
+
+	switch r.Level {
+	case Major, Minor:
+		// All good!
+	default:
+		return fmt.Errorf("unknown level for rule %s: %q, should be major or minor", decoded, r.Level)
+	}
+
+	switch r.Direction {
+	case "", SkewOlder, SkewNewer:
+		// All good!
+	default:
+		return fmt.Errorf("unknown direction for rule %s: %s", decoded, r.Direction)
+	}
+
+	if r.MaxSkew < 0 {
+		return fmt.Errorf("maxSkew of rule %s cannot be negative", decoded)
+	}
+
+	return nil
+}
+
+func (r *Rule) String() string {
+	if r.Name != "" {
+		return r.Name
+	}
+
+	if r.Direction != "" {
+		return fmt.Sprintf("%s at most %d %s versions %s than %s", r.Dependency, r.MaxSkew, r.Level, r.Direction, r.RelativeTo)
+	}
+	return fmt.Sprintf("%s within %d %s versions of %s", r.Dependency, r.MaxSkew, r.Level, r.RelativeTo)
+}
+
+// Check returns an error if the versions of the dependencies, indexed by
+// name, break the rule.
+func (r *Rule) Check(versions map[string]string) error {
+	version, err := r.version(versions, r.Dependency)
+	if err != nil {
+		return err
+	}
+
+	relativeTo, err := r.version(versions, r.RelativeTo)
+	if err != nil {
+		return err
+	}
+
+	skew := int64(version.Major) - int64(relativeTo.Major)
+	if r.Level == Minor {
+		if skew != 0 {
+			return fmt.Errorf(
+				"rule %s is broken: %s %s and %s %s have different major versions",
+				r, r.Dependency, versions[r.Dependency], r.RelativeTo, versions[r.RelativeTo],
+			)
+		}
+		skew = int64(version.Minor) - int64(relativeTo.Minor)
+	}
+
+	broken := skew > int64(r.MaxSkew) || skew < -int64(r.MaxSkew)
+	switch r.Direction {
+	case SkewOlder:
+		broken = broken || skew > 0
+	case SkewNewer:
+		broken = broken || skew < 0
+	}
+
+	if broken {
+		return fmt.Errorf(
+			"rule %s is broken: %s is at %s and %s at %s",
+			r, r.Dependency, versions[r.Dependency], r.RelativeTo, versions[r.RelativeTo],
+		)
+	}
+
+	return nil
+}
+
+func (r *Rule) version(versions map[string]string, name string) (semver.Version, error) {
+	version, ok := versions[name]
+	if !ok {
+		return semver.Version{}, fmt.Errorf("rule %s refers to unknown dependency %s", r, name)
+	}
+
+	version, _ = SplitDigest(version)
+	v, err := semver.ParseTolerant(version)
+	if err != nil {
+		return semver.Version{}, fmt.Errorf("rule %s: version %s of %s does not follow semver: %w", r, version, name, err)
+	}
+
+	return v, nil
+}
+
+// Versions returns the version of each dependency, indexed by name.
+func (d *Dependencies) Versions() map[string]string {
+	versions := make(map[string]string, len(d.Dependencies))
+	for _, dep := range d.Dependencies {
+		versions[dep.Name] = dep.Version
+	}
+	return versions
+}
+
+// CheckRules returns the rules broken by the versions of the dependencies,
+// indexed by name, joined in a single error.
+func CheckRules(rules []*Rule, versions map[string]string) error {
+	var errs []error
+	for _, rule := range rules {
+		if err := rule.Check(versions); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
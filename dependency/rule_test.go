@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDeSerializeRule(t *testing.T) {
+	invalidYamls := []string{
+		"relativeTo: eks\nlevel: minor",
+		"dependency: kubectl\nlevel: minor",
+		"dependency: kubectl\nrelativeTo: eks\nlevel: patch",
+		"dependency: kubectl\nrelativeTo: eks\nlevel: minor\ndirection: sideways",
+		"dependency: kubectl\nrelativeTo: eks\nlevel: minor\nmaxSkew: -1",
+	}
+
+	for _, invalid := range invalidYamls {
+		var r Rule
+
+		err := yaml.Unmarshal([]byte(invalid), &r)
+		require.Error(t, err, invalid)
+	}
+
+	var r Rule
+	err := yaml.Unmarshal([]byte("dependency: kubectl\nrelativeTo: eks\nlevel: minor\nmaxSkew: 1\ndirection: older"), &r)
+	require.NoError(t, err)
+	require.Equal(t, "kubectl at most 1 minor versions older than eks", r.String())
+}
+
+func TestRuleCheck(t *testing.T) {
+	versions := map[string]string{
+		"eks":      "1.30",
+		"kubectl":  "v1.31.2",
+		"node":     "1.27.4",
+		"chart":    "2.3.0",
+		"image":    "2.9.1@sha256:0123",
+		"image-v3": "3.0.0",
+		"commit":   "0123456789abcdef",
+	}
+
+	for _, tc := range []struct {
+		rule  Rule
+		valid bool
+	}{
+		{Rule{Dependency: "kubectl", RelativeTo: "eks", Level: Minor, MaxSkew: 1}, true},
+		{Rule{Dependency: "kubectl", RelativeTo: "eks", Level: Minor}, false},
+		{Rule{Dependency: "kubectl", RelativeTo: "eks", Level: Minor, MaxSkew: 1, Direction: SkewOlder}, false},
+		{Rule{Dependency: "kubectl", RelativeTo: "eks", Level: Minor, MaxSkew: 1, Direction: SkewNewer}, true},
+		{Rule{Dependency: "node", RelativeTo: "eks", Level: Minor, MaxSkew: 3, Direction: SkewOlder}, true},
+		{Rule{Dependency: "node", RelativeTo: "eks", Level: Minor, MaxSkew: 2}, false},
+		{Rule{Dependency: "chart", RelativeTo: "image", Level: Major}, true},
+		{Rule{Dependency: "chart", RelativeTo: "image-v3", Level: Major}, false},
+		{Rule{Dependency: "chart", RelativeTo: "image-v3", Level: Minor, MaxSkew: 10}, false},
+		{Rule{Dependency: "commit", RelativeTo: "eks", Level: Major}, false},
+		{Rule{Dependency: "kubectl", RelativeTo: "does-not-exist", Level: Major}, false},
+	} {
+		err := tc.rule.Check(versions)
+		if tc.valid {
+			require.NoError(t, err, tc.rule.String())
+		} else {
+			require.Error(t, err, tc.rule.String())
+		}
+	}
+}
+
+func TestLocalCheckRules(t *testing.T) {
+	client, err := NewLocalClient()
+	require.NoError(t, err)
+
+	err = client.LocalCheck("../testdata/local-rules.yaml", "../testdata")
+	require.NoError(t, err)
+
+	err = client.LocalCheck("../testdata/local-rules-broken.yaml", "../testdata")
+	require.Error(t, err)
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Staleness measures how far behind upstream the current version of a
+// dependency is.
+type Staleness struct {
+	// NewerReleases is the number of upstream versions newer than the
+	// current one
+	NewerReleases int `json:"newer_releases"          yaml:"newer_releases"`
+	// MajorsBehind is the highest major version distance
+	MajorsBehind int `json:"majors_behind"           yaml:"majors_behind"`
+	// MinorsBehind is the highest minor version distance within the current
+	// major version
+	MinorsBehind int `json:"minors_behind"           yaml:"minors_behind"`
+	// PatchesBehind is the highest patch version distance within the current
+	// minor version
+	PatchesBehind int `json:"patches_behind"          yaml:"patches_behind"`
+	// SupersededAt is when the first newer version was published, if known
+	SupersededAt *time.Time `json:"superseded_at,omitempty" yaml:"superseded_at,omitempty"`
+	// DaysBehind is the number of days since the current version was
+	// superseded, if known
+	DaysBehind int `json:"days_behind,omitempty"   yaml:"days_behind,omitempty"`
+}
+
+func (s *Staleness) String() string {
+	behind := fmt.Sprintf(
+		"%d releases behind (%d major, %d minor, %d patch versions)",
+		s.NewerReleases, s.MajorsBehind, s.MinorsBehind, s.PatchesBehind,
+	)
+	if s.SupersededAt != nil {
+		behind += fmt.Sprintf(", superseded %d days ago", s.DaysBehind)
+	}
+	return behind
+}
+
+// BehindUnit is the unit of a maximum distance behind upstream.
+type BehindUnit string
+
+const (
+	// BehindMajors counts major versions.
+	BehindMajors BehindUnit = "major"
+	// BehindMinors counts minor versions, any newer major version exceeds it.
+	BehindMinors BehindUnit = "minor"
+	// BehindPatches counts patch versions, any newer major or minor version
+	// exceeds it.
+	BehindPatches BehindUnit = "patch"
+	// BehindReleases counts newer releases.
+	BehindReleases BehindUnit = "release"
+)
+
+var behindRegex = regexp.MustCompile(`^(\d+)\s*(major|minor|patch|release)(?:s|es)?$`)
+
+// ParseBehind parses a maximum distance behind upstream such as maxBehind,
+// e.g. "2 minors", "1 major", "3 patches" or "10 releases". An empty distance
+// is no limit, with an empty unit.
+func ParseBehind(behind string) (int, BehindUnit, error) {
+	if behind == "" {
+		return 0, "", nil
+	}
+
+	matches := behindRegex.FindStringSubmatch(strings.TrimSpace(behind))
+	if matches == nil {
+		return 0, "", fmt.Errorf(
+			"%q should be a number of majors, minors, patches or releases, e.g. \"2 minors\"", behind,
+		)
+	}
+
+	count, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return 0, "", err
+	}
+
+	return count, BehindUnit(matches[2]), nil
+}
+
+// CheckStaleness returns an error if the staleness of the dependency exceeds
+// its MaxBehind or MaxAge.
+func (d *Dependency) CheckStaleness(staleness *Staleness) error {
+	if staleness == nil {
+		return nil
+	}
+
+	count, unit, err := ParseBehind(d.MaxBehind)
+	if err != nil {
+		return fmt.Errorf("invalid maxBehind for dependency %s: %w", d.Name, err)
+	}
+
+	var exceeded bool
+	switch unit {
+	case BehindMajors:
+		exceeded = staleness.MajorsBehind > count
+	case BehindMinors:
+		exceeded = staleness.MajorsBehind > 0 || staleness.MinorsBehind > count
+	case BehindPatches:
+		exceeded = staleness.MajorsBehind > 0 || staleness.MinorsBehind > 0 || staleness.PatchesBehind > count
+	case BehindReleases:
+		exceeded = staleness.NewerReleases > count
+	}
+	if exceeded {
+		return fmt.Errorf("dependency %s is %s, more than %s", d.Name, staleness, d.MaxBehind)
+	}
+
+	maxAge, err := ParseAge(d.MaxAge)
+	if err != nil {
+		return fmt.Errorf("invalid maxAge for dependency %s: %w", d.Name, err)
+	}
+	if d.MaxAge != "" && staleness.SupersededAt != nil && time.Since(*staleness.SupersededAt) > maxAge {
+		return fmt.Errorf("dependency %s was superseded %d days ago, more than %s", d.Name, staleness.DaysBehind, d.MaxAge)
+	}
+
+	return nil
+}
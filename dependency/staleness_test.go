@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBehind(t *testing.T) {
+	for behind, expected := range map[string]struct {
+		count int
+		unit  BehindUnit
+	}{
+		"":            {0, ""},
+		"2 minors":    {2, BehindMinors},
+		"1 minor":     {1, BehindMinors},
+		"1 major":     {1, BehindMajors},
+		"3 patches":   {3, BehindPatches},
+		"10 releases": {10, BehindReleases},
+		"0majors":     {0, BehindMajors},
+	} {
+		count, unit, err := ParseBehind(behind)
+		require.NoError(t, err, behind)
+		require.Equal(t, expected.count, count, behind)
+		require.Equal(t, expected.unit, unit, behind)
+	}
+
+	for _, invalid := range []string{"2", "minors", "-1 minor", "2 weeks", "two minors"} {
+		_, _, err := ParseBehind(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestCheckStaleness(t *testing.T) {
+	superseded := time.Now().Add(-45 * 24 * time.Hour)
+	staleness := &Staleness{
+		NewerReleases: 4,
+		MinorsBehind:  2,
+		PatchesBehind: 1,
+		SupersededAt:  &superseded,
+		DaysBehind:    45,
+	}
+	require.Equal(t, "4 releases behind (0 major, 2 minor, 1 patch versions), superseded 45 days ago", staleness.String())
+
+	for _, tc := range []struct {
+		maxBehind string
+		maxAge    string
+		lagging   bool
+	}{
+		{"", "", false},
+		{"0 majors", "", false},
+		{"2 minors", "", false},
+		{"1 minor", "", true},
+		{"5 patches", "", true},
+		{"4 releases", "", false},
+		{"3 releases", "", true},
+		{"", "60d", false},
+		{"", "30d", true},
+		{"2 minors", "7w", false},
+	} {
+		dep := &Dependency{Name: "test", MaxBehind: tc.maxBehind, MaxAge: tc.maxAge}
+		err := dep.CheckStaleness(staleness)
+		if tc.lagging {
+			require.Error(t, err, "%#v", tc)
+		} else {
+			require.NoError(t, err, "%#v", tc)
+		}
+	}
+
+	require.NoError(t, (&Dependency{Name: "test", MaxBehind: "0 releases"}).CheckStaleness(nil))
+}
@@ -22,6 +22,8 @@ import (
 
 	"github.com/blang/semver/v4"
 	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/zeitgeist/pkg/container"
 )
 
 // Version is the internal representation of a Version as a string and a scheme.
@@ -30,6 +32,15 @@ type Version struct {
 	Scheme  VersionScheme
 }
 
+const (
+	// KeyedVersionSeparator separates the versions of a version holding one
+	// version per key, e.g. us-east-1=ami-0123,eu-west-1=ami-4567.
+	KeyedVersionSeparator = ","
+
+	// KeyValueSeparator separates a key from its version in a keyed version.
+	KeyValueSeparator = "="
+)
+
 // VersionScheme informs us on how to compare two versions.
 type VersionScheme string
 
@@ -42,19 +53,99 @@ const (
 	Random VersionScheme = "random"
 )
 
+// PrereleasePolicy informs us on whether pre-releases, e.g. 1.2.0-rc.1, can
+// be proposed as updates.
+type PrereleasePolicy string
+
+const (
+	// PrereleasesExclude never proposes pre-releases.
+	PrereleasesExclude PrereleasePolicy = "exclude"
+	// PrereleasesInclude proposes pre-releases like any other version.
+	PrereleasesInclude PrereleasePolicy = "include"
+	// PrereleasesIfCurrent proposes pre-releases only if the current version
+	// is a pre-release itself, default.
+	PrereleasesIfCurrent PrereleasePolicy = "only-if-current-is-prerelease"
+)
+
 type VersionUpdateInfo struct {
 	Name            string
 	Current         Version
 	Latest          Version
 	UpdateAvailable bool
+	Skipped         []SkippedVersion
+	// Hold is set if the dependency is held at its current version
+	Hold *Hold
+	// Staleness is how far behind the current version is, if known
+	Staleness *Staleness
+	// ReleaseNotes of the versions between the current and latest ones,
+	// newest first, if collected (see WithChangelog)
+	ReleaseNotes []ReleaseNote
 }
 
 // VersionUpdate represents the schema of the output format
 // The output format is dictated by exportOptions.outputFormat.
 type VersionUpdate struct {
-	Name       string `json:"name"        yaml:"name"`
-	Version    string `json:"version"     yaml:"version"`
-	NewVersion string `json:"new_version" yaml:"new_version"`
+	Name       string           `json:"name"                yaml:"name"`
+	Version    string           `json:"version"             yaml:"version"`
+	NewVersion string           `json:"new_version"         yaml:"new_version"`
+	Skipped    []SkippedVersion `json:"skipped,omitempty"   yaml:"skipped,omitempty"`
+	Hold       *Hold            `json:"hold,omitempty"      yaml:"hold,omitempty"`
+	Staleness  *Staleness       `json:"staleness,omitempty" yaml:"staleness,omitempty"`
+	Changelog  string           `json:"changelog,omitempty" yaml:"changelog,omitempty"`
+}
+
+// Export sorts the results of CheckUpstreamVersions for exports: every entry
+// of Updates is an available update, while dependencies without update but
+// with skipped versions or a hold are reported separately in Withheld, with
+// their current version as both Version and NewVersion.
+type Export struct {
+	Updates  []VersionUpdate
+	Withheld []VersionUpdate
+}
+
+// NewExport sorts version update infos into the updates and withheld
+// dependencies of an export.
+func NewExport(versionUpdateInfos []VersionUpdateInfo) *Export {
+	export := &Export{Updates: []VersionUpdate{}}
+
+	for _, vui := range versionUpdateInfos {
+		switch {
+		case vui.UpdateAvailable:
+			export.Updates = append(export.Updates, VersionUpdate{
+				Name:       vui.Name,
+				Version:    vui.Current.Version,
+				NewVersion: vui.Latest.Version,
+				Skipped:    vui.Skipped,
+				Staleness:  vui.Staleness,
+				Changelog:  RenderChangelog(vui.Name, vui.Current.Version, vui.Latest.Version, vui.ReleaseNotes),
+			})
+		case len(vui.Skipped) > 0 || vui.Hold != nil:
+			export.Withheld = append(export.Withheld, VersionUpdate{
+				Name:       vui.Name,
+				Version:    vui.Current.Version,
+				NewVersion: vui.Current.Version,
+				Skipped:    vui.Skipped,
+				Hold:       vui.Hold,
+				Staleness:  vui.Staleness,
+			})
+		default:
+			log.Debugf(
+				"No update available for dependency %s: %s (latest: %s)\n",
+				vui.Name,
+				vui.Current.Version,
+				vui.Latest.Version,
+			)
+		}
+	}
+
+	return export
+}
+
+// SkippedVersion is a newer upstream version which was not proposed as an
+// update, along with the reason why.
+type SkippedVersion struct {
+	Version string `json:"version" yaml:"version"`
+	Reason  string `json:"reason"  yaml:"reason"`
 }
 
 // VersionSensitivity informs us on how to compare whether a version is more
@@ -82,6 +173,10 @@ func (a Version) MoreRecentThan(b Version) (bool, error) {
 // another one, accepting a VersionSensitivity argument
 //
 // If the VersionScheme is "random", then it will return true if a != b.
+//
+// Versions pinned to a digest (see SplitDigest) are compared without it, except
+// when both have the same version: a different digest then means the version
+// was re-published, or b is not pinned yet, and a is considered more recent.
 func (a Version) MoreSensitivelyRecentThan(b Version, sensitivity VersionSensitivity) (bool, error) {
 	// Default to a Patch-level sensitivity
 	if sensitivity == "" {
@@ -92,6 +187,13 @@ func (a Version) MoreSensitivelyRecentThan(b Version, sensitivity VersionSensiti
 		return false, fmt.Errorf("trying to compare incompatible 'Version' schemes: %s and %s", a.Scheme, b.Scheme)
 	}
 
+	var aDigest, bDigest string
+	a.Version, aDigest = SplitDigest(a.Version)
+	b.Version, bDigest = SplitDigest(b.Version)
+	if a.Version == b.Version && aDigest != "" {
+		return aDigest != bDigest, nil
+	}
+
 	switch a.Scheme {
 	case Semver:
 		aSemver, err := semver.ParseTolerant(a.Version)
@@ -118,6 +220,29 @@ func (a Version) MoreSensitivelyRecentThan(b Version, sensitivity VersionSensiti
 	}
 }
 
+// SplitDigest splits a version pinned to a content digest, e.g.
+// 1.25.3@sha256:0123456789abcdef, into the version and the digest.
+//
+// The digest is empty if the version isn't pinned.
+func SplitDigest(version string) (string, string) {
+	v, digest, _ := strings.Cut(version, container.DigestSeparator)
+	return v, digest
+}
+
+// KeyedVersion returns the version for the given key from a version holding
+// one version per key, e.g. one AMI per region:
+// us-east-1=ami-0123,eu-west-1=ami-4567.
+func KeyedVersion(version, key string) (string, bool) {
+	for _, kv := range strings.Split(version, KeyedVersionSeparator) {
+		k, v, found := strings.Cut(kv, KeyValueSeparator)
+		if found && strings.TrimSpace(k) == key {
+			return strings.TrimSpace(v), true
+		}
+	}
+
+	return "", false
+}
+
 // semverCompare compares two semver versions depending on a sensitivity level.
 func semverCompare(a, b semver.Version, sensitivity VersionSensitivity) (bool, error) {
 	switch sensitivity {
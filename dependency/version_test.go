@@ -184,3 +184,126 @@ func TestFormatVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestDigestVersions(t *testing.T) {
+	tests := []struct {
+		name string
+		a    Version
+		b    Version
+		want bool
+	}{
+		{
+			name: "Newer version, both pinned",
+			a:    Version{"1.1.0@sha256:bbbb", Semver},
+			b:    Version{"1.0.0@sha256:aaaa", Semver},
+			want: true,
+		},
+		{
+			name: "Older version, both pinned",
+			a:    Version{"1.0.0@sha256:bbbb", Semver},
+			b:    Version{"1.1.0@sha256:aaaa", Semver},
+			want: false,
+		},
+		{
+			name: "Same version and digest",
+			a:    Version{"1.0.0@sha256:aaaa", Semver},
+			b:    Version{"1.0.0@sha256:aaaa", Semver},
+			want: false,
+		},
+		{
+			name: "Same version re-published with a new digest",
+			a:    Version{"1.0.0@sha256:bbbb", Semver},
+			b:    Version{"1.0.0@sha256:aaaa", Semver},
+			want: true,
+		},
+		{
+			name: "Version not pinned yet",
+			a:    Version{"1.0.0@sha256:aaaa", Semver},
+			b:    Version{"1.0.0", Semver},
+			want: true,
+		},
+		{
+			name: "Pinned version against an unpinned one",
+			a:    Version{"1.0.0", Semver},
+			b:    Version{"1.0.0@sha256:aaaa", Semver},
+			want: false,
+		},
+		{
+			name: "Alpha version re-published with a new digest",
+			a:    Version{"release-b@sha256:bbbb", Alpha},
+			b:    Version{"release-b@sha256:aaaa", Alpha},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.a.MoreRecentThan(tt.b)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestKeyedVersion(t *testing.T) {
+	version := "us-east-1=ami-0123, eu-west-1=ami-4567"
+
+	v, ok := KeyedVersion(version, "us-east-1")
+	require.True(t, ok)
+	require.Equal(t, "ami-0123", v)
+
+	v, ok = KeyedVersion(version, "eu-west-1")
+	require.True(t, ok)
+	require.Equal(t, "ami-4567", v)
+
+	_, ok = KeyedVersion(version, "ap-south-1")
+	require.False(t, ok)
+
+	_, ok = KeyedVersion("ami-0123", "us-east-1")
+	require.False(t, ok)
+}
+
+func TestNewExport(t *testing.T) {
+	hold := &Hold{Until: "2999-01-01"}
+	export := NewExport([]VersionUpdateInfo{
+		{
+			Name:            "update",
+			Current:         Version{Version: "1.0.0"},
+			Latest:          Version{Version: "1.1.0"},
+			UpdateAvailable: true,
+			Skipped:         []SkippedVersion{{Version: "1.2.0", Reason: "missing platforms linux/arm64"}},
+		},
+		{
+			Name:    "skipped",
+			Current: Version{Version: "1.0.0"},
+			Latest:  Version{Version: "1.0.0"},
+			Skipped: []SkippedVersion{{Version: "1.1.0", Reason: "ignored"}},
+		},
+		{
+			Name:    "held",
+			Current: Version{Version: "1.0.0"},
+			Latest:  Version{Version: "1.0.0"},
+			Hold:    hold,
+		},
+		{
+			Name:    "up-to-date",
+			Current: Version{Version: "1.0.0"},
+			Latest:  Version{Version: "1.0.0"},
+		},
+	})
+
+	// Every update is an available update
+	require.Equal(t, []VersionUpdate{{
+		Name:       "update",
+		Version:    "1.0.0",
+		NewVersion: "1.1.0",
+		Skipped:    []SkippedVersion{{Version: "1.2.0", Reason: "missing platforms linux/arm64"}},
+	}}, export.Updates)
+
+	require.Equal(t, []VersionUpdate{
+		{Name: "skipped", Version: "1.0.0", NewVersion: "1.0.0", Skipped: []SkippedVersion{{Version: "1.1.0", Reason: "ignored"}}},
+		{Name: "held", Version: "1.0.0", NewVersion: "1.0.0", Hold: hold},
+	}, export.Withheld)
+
+	require.Equal(t, []VersionUpdate{}, NewExport(nil).Updates)
+}
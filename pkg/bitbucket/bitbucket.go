@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bitbucket is a minimal client for the Bitbucket Cloud REST API.
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-utils/env"
+)
+
+const (
+	// DefaultServer is the Bitbucket Cloud API server.
+	DefaultServer = "https://api.bitbucket.org"
+	// TokenEnvKey is the default Bitbucket token environment variable key.
+	TokenEnvKey = "BITBUCKET_TOKEN"
+	// UserNameEnvKey is the Bitbucket user name environment variable key, used
+	// along with the token when it is an app password.
+	UserNameEnvKey = "BITBUCKET_USERNAME"
+	apiVersionPath = "2.0/"
+	pageSize       = 100
+)
+
+// Bitbucket is a wrapper around Bitbucket related functionality.
+type Bitbucket struct {
+	baseURL  string
+	username string
+	token    string
+	client   *http.Client
+}
+
+// Ref is the subset of a Bitbucket tag or branch used by Zeitgeist.
+type Ref struct {
+	Name   string `json:"name"`
+	Target struct {
+		Hash string    `json:"hash"`
+		Date time.Time `json:"date"`
+	} `json:"target"`
+}
+
+type refsPage struct {
+	Values []*Ref `json:"values"`
+	Next   string `json:"next"`
+}
+
+// New creates a new Bitbucket client for the given server, or Bitbucket Cloud
+// if server is empty.
+//
+// The token is read from the tokenEnv environment variable, or $BITBUCKET_TOKEN
+// if tokenEnv is empty. It is sent as a bearer token, unless $BITBUCKET_USERNAME
+// is set, in which case it is used as an app password. An empty token results
+// in unauthenticated requests.
+func New(server, tokenEnv string) *Bitbucket {
+	if server == "" {
+		server = DefaultServer
+	}
+
+	if tokenEnv == "" {
+		tokenEnv = TokenEnvKey
+	}
+
+	token := env.Default(tokenEnv, "")
+	if token == "" {
+		logrus.Debugf("No %s configured, using unauthenticated Bitbucket client", tokenEnv)
+	}
+
+	return &Bitbucket{
+		baseURL:  strings.TrimSuffix(server, "/") + "/" + apiVersionPath,
+		username: env.Default(UserNameEnvKey, ""),
+		token:    token,
+		client:   http.DefaultClient,
+	}
+}
+
+// ListTags returns a list of Bitbucket tags for the provided `workspace` and
+// `repo`.
+func (b *Bitbucket) ListTags(workspace, repo string) ([]*Ref, error) {
+	var allTags []*Ref
+
+	next := fmt.Sprintf("%srepositories/%s/%s/refs/tags?pagelen=%d", b.baseURL, workspace, repo, pageSize)
+	for next != "" {
+		page := &refsPage{}
+		if err := b.get(next, page); err != nil {
+			return nil, fmt.Errorf("unable to retrieve Bitbucket tags for %s/%s: %w", workspace, repo, err)
+		}
+
+		allTags = append(allTags, page.Values...)
+		next = page.Next
+	}
+
+	return allTags, nil
+}
+
+// GetBranch returns the Ref of `branch` for the provided `workspace` and
+// `repo`.
+func (b *Bitbucket) GetBranch(workspace, repo, branch string) (*Ref, error) {
+	ref := &Ref{}
+	u := fmt.Sprintf("%srepositories/%s/%s/refs/branches/%s", b.baseURL, workspace, repo, url.PathEscape(branch))
+	if err := b.get(u, ref); err != nil {
+		return nil, fmt.Errorf("unable to retrieve Bitbucket branch %s for %s/%s: %w", branch, workspace, repo, err)
+	}
+
+	return ref, nil
+}
+
+func (b *Bitbucket) get(u string, into interface{}) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	switch {
+	case b.token == "":
+	case b.username != "":
+		req.SetBasicAuth(b.username, b.token)
+	default:
+		req.Header.Set("Authorization", "Bearer "+b.token)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(into)
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/zeitgeist/pkg/bitbucket"
+)
+
+// bitbucketServer fakes the Bitbucket Cloud API for honk/honk: tags are
+// served in pages linked by `next` URLs with an opaque cursor, as Bitbucket
+// does, and release/1.0 is the only branch.
+type bitbucketServer struct {
+	*httptest.Server
+
+	tags  int
+	pages int
+}
+
+func newBitbucketServer(t *testing.T, tags int) *bitbucketServer {
+	t.Helper()
+
+	s := &bitbucketServer{tags: tags}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.EscapedPath() {
+		case "/2.0/repositories/honk/honk/refs/tags":
+			s.pages++
+
+			pageLen, err := strconv.Atoi(req.URL.Query().Get("pagelen"))
+			require.NoError(t, err)
+			start := 0
+			if cursor := req.URL.Query().Get("cursor"); cursor != "" {
+				decoded, err := base64.URLEncoding.DecodeString(cursor)
+				require.NoError(t, err)
+				start, err = strconv.Atoi(string(decoded))
+				require.NoError(t, err)
+			}
+
+			values := []map[string]interface{}{}
+			for i := start; i < start+pageLen && i < s.tags; i++ {
+				values = append(values, map[string]interface{}{
+					"type":   "tag",
+					"name":   fmt.Sprintf("v0.%d.0", i),
+					"target": map[string]string{"hash": fmt.Sprintf("%040x", i)},
+				})
+			}
+			body := map[string]interface{}{"pagelen": pageLen, "values": values}
+			if end := start + pageLen; end < s.tags {
+				cursor := base64.URLEncoding.EncodeToString([]byte(strconv.Itoa(end)))
+				body["next"] = fmt.Sprintf("%s%s?pagelen=%d&cursor=%s", s.URL, req.URL.Path, pageLen, cursor)
+			}
+			require.NoError(t, json.NewEncoder(rw).Encode(body))
+
+		case "/2.0/repositories/honk/honk/refs/branches/release%2F1.0":
+			fmt.Fprint(rw, `{"name": "release/1.0", "target": {"hash": "abc123", "date": "2026-01-02T03:04:05+00:00"}}`)
+
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return s
+}
+
+func TestListTagsPaginated(t *testing.T) {
+	for total, pages := range map[int]int{0: 1, 3: 1, 100: 1, 250: 3} {
+		server := newBitbucketServer(t, total)
+
+		tags, err := bitbucket.New(server.URL, "").ListTags("honk", "honk")
+		require.NoError(t, err)
+		require.Len(t, tags, total)
+		require.Equal(t, pages, server.pages)
+		if total > 0 {
+			require.Equal(t, fmt.Sprintf("v0.%d.0", total-1), tags[total-1].Name)
+		}
+
+		server.Close()
+	}
+}
+
+func TestListTagsFailed(t *testing.T) {
+	server := newBitbucketServer(t, 0)
+	defer server.Close()
+
+	_, err := bitbucket.New(server.URL, "").ListTags("honk", "doesnotexist")
+	require.ErrorContains(t, err, "unable to retrieve Bitbucket tags for honk/doesnotexist: unexpected status: 404 Not Found")
+}
+
+func TestGetBranch(t *testing.T) {
+	server := newBitbucketServer(t, 0)
+	defer server.Close()
+
+	b := bitbucket.New(server.URL, "")
+
+	branch, err := b.GetBranch("honk", "honk", "release/1.0")
+	require.NoError(t, err)
+	require.Equal(t, "release/1.0", branch.Name)
+	require.Equal(t, "abc123", branch.Target.Hash)
+
+	_, err = b.GetBranch("honk", "honk", "doesnotexist")
+	require.ErrorContains(t, err, "unable to retrieve Bitbucket branch doesnotexist for honk/honk")
+}
+
+func TestAuthentication(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		authorization = req.Header.Get("Authorization")
+		fmt.Fprint(rw, `{"values": []}`)
+	}))
+	defer server.Close()
+
+	t.Setenv("HONK_TOKEN", "secret")
+	t.Setenv(bitbucket.UserNameEnvKey, "")
+
+	_, err := bitbucket.New(server.URL, "HONK_TOKEN").ListTags("honk", "honk")
+	require.NoError(t, err)
+	require.Equal(t, "Bearer secret", authorization)
+
+	// With a user name, the token is an app password
+	t.Setenv(bitbucket.UserNameEnvKey, "goose")
+	_, err = bitbucket.New(server.URL, "HONK_TOKEN").ListTags("honk", "honk")
+	require.NoError(t, err)
+	require.Equal(t, "Basic Z29vc2U6c2VjcmV0", authorization)
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package container
 
 import (
+	"fmt"
+
 	"github.com/google/go-containerregistry/pkg/authn"
 	containerregistry "github.com/google/go-containerregistry/pkg/crane"
 
@@ -28,10 +30,17 @@ const (
 	RegistryUserName = "REGISTRY_USERNAME"
 )
 
+// DigestSeparator separates a tag from the content digest it is pinned to,
+// e.g. 1.25.3@sha256:0123456789abcdef.
+const DigestSeparator = "@"
+
 // Container is a wrapper around Container related functionality.
 type Container struct {
 	client Client
 	Auth   authn.Basic
+
+	// Keychain is used to resolve credentials when no Auth is set.
+	Keychain authn.Keychain
 }
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -40,19 +49,40 @@ type Client interface {
 	ListTags(
 		src string,
 	) ([]string, error)
+	Digest(
+		ref string,
+	) (string, error)
+	Platforms(
+		ref string,
+	) ([]string, error)
+	Config(
+		ref string,
+	) ([]byte, error)
 }
 
 // New creates a new default container client. Tokens set via the $REGISTRY_USER_PASSWORD
 // and $REGISTRY_USERNAME environment variable will result in an authenticated client.
 func New() *Container {
-	passwd := env.Default(RegistryPassword, "")
-	username := env.Default(RegistryUserName, "")
+	return NewFromEnv(RegistryUserName, RegistryPassword)
+}
+
+// NewFromEnv creates a new container client authenticated with the username
+// and password read from the `usernameEnv` and `passwordEnv` environment
+// variables.
+//
+// If either is empty, credentials are resolved per registry from the Docker
+// config file (~/.docker/config.json, or $DOCKER_CONFIG), including its
+// credential helpers.
+func NewFromEnv(usernameEnv, passwordEnv string) *Container {
+	passwd := env.Default(passwordEnv, "")
+	username := env.Default(usernameEnv, "")
 
 	return &Container{
 		Auth: authn.Basic{
 			Password: passwd,
 			Username: username,
 		},
+		Keychain: authn.DefaultKeychain,
 	}
 }
 
@@ -70,12 +100,84 @@ func (c *Container) Client() Client {
 func (c *Container) ListTags(
 	src string,
 ) ([]string, error) {
+	return containerregistry.ListTags(src, c.options()...)
+}
+
+// Digest returns the digest of the image referenced by `ref`, e.g.
+// registry.k8s.io/pause:3.9.
+func (c *Container) Digest(
+	ref string,
+) (string, error) {
+	return containerregistry.Digest(ref, c.options()...)
+}
+
+// Config returns the raw configuration of the artifact referenced by `ref`,
+// e.g. the chart metadata of a Helm chart.
+func (c *Container) Config(
+	ref string,
+) ([]byte, error) {
+	return containerregistry.Config(ref, c.options()...)
+}
+
+// Platforms returns the platforms provided by the image referenced by `ref`,
+// e.g. linux/arm64/v8. Image indexes provide one platform per manifest, while
+// single images provide the platform from their configuration.
+func (c *Container) Platforms(
+	ref string,
+) ([]string, error) {
+	desc, err := containerregistry.Get(ref, c.options()...)
+	if err != nil {
+		return nil, err
+	}
+
+	if desc.MediaType.IsIndex() {
+		index, err := desc.ImageIndex()
+		if err != nil {
+			return nil, fmt.Errorf("reading image index %s: %w", ref, err)
+		}
+
+		manifest, err := index.IndexManifest()
+		if err != nil {
+			return nil, fmt.Errorf("reading index manifest %s: %w", ref, err)
+		}
+
+		platforms := make([]string, 0, len(manifest.Manifests))
+		for _, m := range manifest.Manifests {
+			if m.Platform != nil {
+				platforms = append(platforms, m.Platform.String())
+			}
+		}
+
+		return platforms, nil
+	}
+
+	img, err := desc.Image()
+	if err != nil {
+		return nil, fmt.Errorf("reading image %s: %w", ref, err)
+	}
+
+	config, err := img.ConfigFile()
+	if err != nil {
+		return nil, fmt.Errorf("reading image config %s: %w", ref, err)
+	}
+
+	if platform := config.Platform(); platform != nil {
+		return []string{platform.String()}, nil
+	}
+
+	return nil, nil
+}
+
+func (c *Container) options() []containerregistry.Option {
 	if c.Auth.Username != "" && c.Auth.Password != "" {
-		opts := containerregistry.WithAuth(&c.Auth)
-		return containerregistry.ListTags(src, opts)
+		return []containerregistry.Option{containerregistry.WithAuth(&c.Auth)}
 	}
 
 	// If Username/Password for the registry aren't supplied
 	// it will use the credentials configured in the docker config file.
-	return containerregistry.ListTags(src)
+	if c.Keychain != nil {
+		return []containerregistry.Option{containerregistry.WithAuthFromKeychain(c.Keychain)}
+	}
+
+	return nil
 }
@@ -17,9 +17,27 @@ limitations under the License.
 package container_test
 
 import (
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/stretchr/testify/require"
 
 	"sigs.k8s.io/zeitgeist/pkg/container"
@@ -71,3 +89,112 @@ func TestTagsSuccess(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, res, 3)
 }
+
+// newAuthenticatedRegistry starts an in-memory registry only accepting the
+// given basic auth credentials, with a single honk/honk:1.0.0 image.
+func newAuthenticatedRegistry(t *testing.T, username, password string) string {
+	t.Helper()
+
+	reg := registry.New(registry.Logger(log.New(io.Discard, "", 0)))
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		user, pass, ok := req.BasicAuth()
+		if !ok || user != username || pass != password {
+			rw.Header().Set("WWW-Authenticate", `Basic realm="honk"`)
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		reg.ServeHTTP(rw, req)
+	}))
+	t.Cleanup(server.Close)
+
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	img, err := random.Image(64, 1)
+	require.NoError(t, err)
+	require.NoError(t, crane.Push(img, host+"/honk/honk:1.0.0", crane.WithAuth(&authn.Basic{
+		Username: username,
+		Password: password,
+	})))
+
+	return host
+}
+
+func TestListTagsEnvCredentials(t *testing.T) {
+	host := newAuthenticatedRegistry(t, "honk", "goose")
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	t.Setenv("HONK_USERNAME", "honk")
+	t.Setenv("HONK_PASSWORD", "goose")
+
+	tags, err := container.NewFromEnv("HONK_USERNAME", "HONK_PASSWORD").ListTags(host + "/honk/honk")
+	require.NoError(t, err)
+	require.Equal(t, []string{"1.0.0"}, tags)
+
+	t.Setenv("HONK_PASSWORD", "duck")
+	_, err = container.NewFromEnv("HONK_USERNAME", "HONK_PASSWORD").ListTags(host + "/honk/honk")
+	require.Error(t, err)
+}
+
+func TestListTagsDockerConfig(t *testing.T) {
+	host := newAuthenticatedRegistry(t, "honk", "goose")
+	t.Setenv(container.RegistryUserName, "")
+	t.Setenv(container.RegistryPassword, "")
+
+	dockerConfig := t.TempDir()
+	t.Setenv("DOCKER_CONFIG", dockerConfig)
+
+	// Without any credentials
+	_, err := container.New().ListTags(host + "/honk/honk")
+	require.Error(t, err)
+
+	auth := base64.StdEncoding.EncodeToString([]byte("honk:goose"))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dockerConfig, "config.json"),
+		[]byte(fmt.Sprintf(`{"auths": {%q: {"auth": %q}}}`, host, auth)),
+		0o600,
+	))
+
+	tags, err := container.New().ListTags(host + "/honk/honk")
+	require.NoError(t, err)
+	require.Equal(t, []string{"1.0.0"}, tags)
+}
+
+func TestPlatforms(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	repo := strings.TrimPrefix(server.URL, "http://") + "/honk/honk"
+
+	var adds []mutate.IndexAddendum
+	for _, platform := range []v1.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm64", Variant: "v8"},
+	} {
+		img, err := random.Image(64, 1)
+		require.NoError(t, err)
+		adds = append(adds, mutate.IndexAddendum{
+			Add:        img,
+			Descriptor: v1.Descriptor{Platform: &platform},
+		})
+	}
+	ref, err := name.ParseReference(repo + ":multi")
+	require.NoError(t, err)
+	require.NoError(t, remote.WriteIndex(ref, mutate.AppendManifests(empty.Index, adds...)))
+
+	img, err := random.Image(64, 1)
+	require.NoError(t, err)
+	img, err = mutate.ConfigFile(img, &v1.ConfigFile{OS: "linux", Architecture: "amd64"})
+	require.NoError(t, err)
+	require.NoError(t, crane.Push(img, repo+":single"))
+
+	sut := container.New()
+
+	platforms, err := sut.Platforms(repo + ":multi")
+	require.NoError(t, err)
+	require.Equal(t, []string{"linux/amd64", "linux/arm64/v8"}, platforms)
+
+	platforms, err = sut.Platforms(repo + ":single")
+	require.NoError(t, err)
+	require.Equal(t, []string{"linux/amd64"}, platforms)
+
+	_, err = sut.Platforms(repo + ":missing")
+	require.Error(t, err)
+}
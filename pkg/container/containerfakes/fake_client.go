@@ -8,6 +8,32 @@ import (
 )
 
 type FakeClient struct {
+	ConfigStub        func(string) ([]byte, error)
+	configMutex       sync.RWMutex
+	configArgsForCall []struct {
+		arg1 string
+	}
+	configReturns struct {
+		result1 []byte
+		result2 error
+	}
+	configReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	DigestStub        func(string) (string, error)
+	digestMutex       sync.RWMutex
+	digestArgsForCall []struct {
+		arg1 string
+	}
+	digestReturns struct {
+		result1 string
+		result2 error
+	}
+	digestReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	ListTagsStub        func(string) ([]string, error)
 	listTagsMutex       sync.RWMutex
 	listTagsArgsForCall []struct {
@@ -21,10 +47,151 @@ type FakeClient struct {
 		result1 []string
 		result2 error
 	}
+	PlatformsStub        func(string) ([]string, error)
+	platformsMutex       sync.RWMutex
+	platformsArgsForCall []struct {
+		arg1 string
+	}
+	platformsReturns struct {
+		result1 []string
+		result2 error
+	}
+	platformsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeClient) Config(arg1 string) ([]byte, error) {
+	fake.configMutex.Lock()
+	ret, specificReturn := fake.configReturnsOnCall[len(fake.configArgsForCall)]
+	fake.configArgsForCall = append(fake.configArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ConfigStub
+	fakeReturns := fake.configReturns
+	fake.recordInvocation("Config", []interface{}{arg1})
+	fake.configMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ConfigCallCount() int {
+	fake.configMutex.RLock()
+	defer fake.configMutex.RUnlock()
+	return len(fake.configArgsForCall)
+}
+
+func (fake *FakeClient) ConfigCalls(stub func(string) ([]byte, error)) {
+	fake.configMutex.Lock()
+	defer fake.configMutex.Unlock()
+	fake.ConfigStub = stub
+}
+
+func (fake *FakeClient) ConfigArgsForCall(i int) string {
+	fake.configMutex.RLock()
+	defer fake.configMutex.RUnlock()
+	argsForCall := fake.configArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) ConfigReturns(result1 []byte, result2 error) {
+	fake.configMutex.Lock()
+	defer fake.configMutex.Unlock()
+	fake.ConfigStub = nil
+	fake.configReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ConfigReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.configMutex.Lock()
+	defer fake.configMutex.Unlock()
+	fake.ConfigStub = nil
+	if fake.configReturnsOnCall == nil {
+		fake.configReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.configReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) Digest(arg1 string) (string, error) {
+	fake.digestMutex.Lock()
+	ret, specificReturn := fake.digestReturnsOnCall[len(fake.digestArgsForCall)]
+	fake.digestArgsForCall = append(fake.digestArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.DigestStub
+	fakeReturns := fake.digestReturns
+	fake.recordInvocation("Digest", []interface{}{arg1})
+	fake.digestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) DigestCallCount() int {
+	fake.digestMutex.RLock()
+	defer fake.digestMutex.RUnlock()
+	return len(fake.digestArgsForCall)
+}
+
+func (fake *FakeClient) DigestCalls(stub func(string) (string, error)) {
+	fake.digestMutex.Lock()
+	defer fake.digestMutex.Unlock()
+	fake.DigestStub = stub
+}
+
+func (fake *FakeClient) DigestArgsForCall(i int) string {
+	fake.digestMutex.RLock()
+	defer fake.digestMutex.RUnlock()
+	argsForCall := fake.digestArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) DigestReturns(result1 string, result2 error) {
+	fake.digestMutex.Lock()
+	defer fake.digestMutex.Unlock()
+	fake.DigestStub = nil
+	fake.digestReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) DigestReturnsOnCall(i int, result1 string, result2 error) {
+	fake.digestMutex.Lock()
+	defer fake.digestMutex.Unlock()
+	fake.DigestStub = nil
+	if fake.digestReturnsOnCall == nil {
+		fake.digestReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.digestReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) ListTags(arg1 string) ([]string, error) {
 	fake.listTagsMutex.Lock()
 	ret, specificReturn := fake.listTagsReturnsOnCall[len(fake.listTagsArgsForCall)]
@@ -89,11 +256,81 @@ func (fake *FakeClient) ListTagsReturnsOnCall(i int, result1 []string, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeClient) Platforms(arg1 string) ([]string, error) {
+	fake.platformsMutex.Lock()
+	ret, specificReturn := fake.platformsReturnsOnCall[len(fake.platformsArgsForCall)]
+	fake.platformsArgsForCall = append(fake.platformsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.PlatformsStub
+	fakeReturns := fake.platformsReturns
+	fake.recordInvocation("Platforms", []interface{}{arg1})
+	fake.platformsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) PlatformsCallCount() int {
+	fake.platformsMutex.RLock()
+	defer fake.platformsMutex.RUnlock()
+	return len(fake.platformsArgsForCall)
+}
+
+func (fake *FakeClient) PlatformsCalls(stub func(string) ([]string, error)) {
+	fake.platformsMutex.Lock()
+	defer fake.platformsMutex.Unlock()
+	fake.PlatformsStub = stub
+}
+
+func (fake *FakeClient) PlatformsArgsForCall(i int) string {
+	fake.platformsMutex.RLock()
+	defer fake.platformsMutex.RUnlock()
+	argsForCall := fake.platformsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) PlatformsReturns(result1 []string, result2 error) {
+	fake.platformsMutex.Lock()
+	defer fake.platformsMutex.Unlock()
+	fake.PlatformsStub = nil
+	fake.platformsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) PlatformsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.platformsMutex.Lock()
+	defer fake.platformsMutex.Unlock()
+	fake.PlatformsStub = nil
+	if fake.platformsReturnsOnCall == nil {
+		fake.platformsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.platformsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.configMutex.RLock()
+	defer fake.configMutex.RUnlock()
+	fake.digestMutex.RLock()
+	defer fake.digestMutex.RUnlock()
 	fake.listTagsMutex.RLock()
 	defer fake.listTagsMutex.RUnlock()
+	fake.platformsMutex.RLock()
+	defer fake.platformsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package endoflife is a minimal client for the endoflife.date API, which
+// lists the release cycles of products along with their support windows.
+package endoflife
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultServer is the public endoflife.date server.
+const DefaultServer = "https://endoflife.date"
+
+// Cycle is the subset of an endoflife.date release cycle used by Zeitgeist.
+type Cycle struct {
+	// Cycle name, e.g. 1.29 for Kubernetes or 22 for Node.js
+	Cycle string
+	// Latest release of the cycle, e.g. 1.29.3, or 1.29-eks-25 for EKS
+	Latest string
+	// EOL is the end-of-life date of the cycle, zero if unknown
+	EOL time.Time
+	// Ended is true if the cycle reached its end of life without a known
+	// date
+	Ended bool
+}
+
+// cycleJSON is the raw representation of a cycle, where `cycle` can be a
+// string or a number, and `eol` a date or a boolean.
+type cycleJSON struct {
+	Cycle  json.RawMessage `json:"cycle"`
+	Latest string          `json:"latest"`
+	EOL    json.RawMessage `json:"eol"`
+}
+
+// UnmarshalJSON decodes an endoflife.date cycle.
+func (c *Cycle) UnmarshalJSON(b []byte) error {
+	var raw cycleJSON
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	c.Cycle = strings.Trim(string(raw.Cycle), `"`)
+	c.Latest = raw.Latest
+	if len(raw.EOL) == 0 {
+		return nil
+	}
+
+	var ended bool
+	if err := json.Unmarshal(raw.EOL, &ended); err == nil {
+		c.Ended = ended
+		return nil
+	}
+
+	var eol string
+	if err := json.Unmarshal(raw.EOL, &eol); err != nil {
+		return fmt.Errorf("invalid eol for cycle %s: %s", c.Cycle, raw.EOL)
+	}
+
+	date, err := time.Parse(time.DateOnly, eol)
+	if err != nil {
+		return fmt.Errorf("invalid eol for cycle %s: %w", c.Cycle, err)
+	}
+	c.EOL = date
+
+	return nil
+}
+
+// Client queries the endoflife.date API.
+type Client struct {
+	server string
+	client *http.Client
+}
+
+// New creates a new client for the endoflife.date API at `server`, or
+// DefaultServer if empty.
+func New(server string) *Client {
+	if server == "" {
+		server = DefaultServer
+	}
+
+	return &Client{
+		server: strings.TrimSuffix(server, "/"),
+		client: http.DefaultClient,
+	}
+}
+
+// Cycles returns the release cycles of `product`, e.g. kubernetes.
+func (c *Client) Cycles(product string) ([]*Cycle, error) {
+	req, err := http.NewRequestWithContext(
+		context.Background(), http.MethodGet, fmt.Sprintf("%s/api/%s.json", c.server, url.PathEscape(product)), http.NoBody,
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve cycles of %s: %w", product, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to retrieve cycles of %s: unexpected status: %s", product, resp.Status)
+	}
+
+	return decodeCycles(resp.Body)
+}
+
+// FromFile reads release cycles from a local JSON file, in the format of the
+// endoflife.date API, e.g. https://endoflife.date/api/kubernetes.json.
+func FromFile(path string) ([]*Cycle, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	cycles, err := decodeCycles(f)
+	if err != nil {
+		return nil, fmt.Errorf("reading cycles from %s: %w", path, err)
+	}
+	return cycles, nil
+}
+
+func decodeCycles(r io.Reader) ([]*Cycle, error) {
+	var cycles []*Cycle
+	if err := json.NewDecoder(r).Decode(&cycles); err != nil {
+		return nil, err
+	}
+	return cycles, nil
+}
+
+// MatchCycle returns the cycle a version belongs to, i.e. the longest cycle
+// which is either the version itself or one of its prefixes, e.g. 1.29 for
+// 1.29.3. A leading "v" is ignored.
+func MatchCycle(cycles []*Cycle, version string) *Cycle {
+	version = strings.TrimPrefix(version, "v")
+
+	var match *Cycle
+	for _, cycle := range cycles {
+		if version != cycle.Cycle && !strings.HasPrefix(version, cycle.Cycle+".") {
+			continue
+		}
+		if match == nil || len(cycle.Cycle) > len(match.Cycle) {
+			match = cycle
+		}
+	}
+
+	return match
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endoflife_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/zeitgeist/pkg/endoflife"
+)
+
+func TestCycles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/api/nodejs.json":
+			fmt.Fprint(rw, `[
+				{"cycle": "23", "eol": "2025-06-01", "latest": "23.11.1"},
+				{"cycle": 22, "eol": "2027-04-30", "lts": "2024-10-29"},
+				{"cycle": "0.10", "eol": true},
+				{"cycle": "24"}
+			]`)
+		case "/api/broken.json":
+			fmt.Fprint(rw, `[{"cycle": "1", "eol": "tomorrow"}]`)
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cycles, err := endoflife.New(server.URL + "/").Cycles("nodejs")
+	require.NoError(t, err)
+	require.Equal(t, []*endoflife.Cycle{
+		{Cycle: "23", Latest: "23.11.1", EOL: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{Cycle: "22", EOL: time.Date(2027, 4, 30, 0, 0, 0, 0, time.UTC)},
+		{Cycle: "0.10", Ended: true},
+		{Cycle: "24"},
+	}, cycles)
+
+	_, err = endoflife.New(server.URL).Cycles("broken")
+	require.Error(t, err)
+
+	_, err = endoflife.New(server.URL).Cycles("does-not-exist")
+	require.Error(t, err)
+}
+
+func TestFromFile(t *testing.T) {
+	cycles, err := endoflife.FromFile("../../testdata/eol/kubernetes.json")
+	require.NoError(t, err)
+	require.Len(t, cycles, 4)
+
+	_, err = endoflife.FromFile("../../testdata/eol/does-not-exist.json")
+	require.Error(t, err)
+}
+
+func TestMatchCycle(t *testing.T) {
+	cycles := []*endoflife.Cycle{{Cycle: "1.2"}, {Cycle: "1.29"}, {Cycle: "1"}, {Cycle: "22.04"}}
+
+	for version, expected := range map[string]string{
+		"1.29.3":      "1.29",
+		"v1.29.3":     "1.29",
+		"1.2.7":       "1.2",
+		"1.28.0":      "1",
+		"22.04":       "22.04",
+		"22.10":       "",
+		"1.290.0":     "1",
+		"2.0.0":       "",
+		"1.2-special": "1",
+	} {
+		cycle := endoflife.MatchCycle(cycles, version)
+		if expected == "" {
+			require.Nil(t, cycle, version)
+			continue
+		}
+		require.NotNil(t, cycle, version)
+		require.Equal(t, expected, cycle.Cycle, version)
+	}
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+// Worktree is a local repository with a worktree, used to commit changes on
+// branches and push them.
+type Worktree struct {
+	repo     *git.Repository
+	worktree *git.Worktree
+}
+
+// OpenWorktree opens the repository containing path.
+func OpenWorktree(path string) (*Worktree, error) {
+	repo, err := git.PlainOpenWithOptions(path, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("opening git repository at %s: %w", path, err)
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Worktree{repo: repo, worktree: worktree}, nil
+}
+
+// Root returns the root directory of the worktree.
+func (w *Worktree) Root() string {
+	return w.worktree.Filesystem.Root()
+}
+
+// Branch returns the name of the checked out branch.
+func (w *Worktree) Branch() (string, error) {
+	head, err := w.repo.Head()
+	if err != nil {
+		return "", err
+	}
+
+	if !head.Name().IsBranch() {
+		return "", errors.New("HEAD is not on a branch")
+	}
+
+	return head.Name().Short(), nil
+}
+
+// RemoteURL returns the first URL of a remote.
+func (w *Worktree) RemoteURL(remote string) (string, error) {
+	r, err := w.repo.Remote(remote)
+	if err != nil {
+		return "", fmt.Errorf("getting remote %s: %w", remote, err)
+	}
+
+	return r.Config().URLs[0], nil
+}
+
+// Changes returns the paths of the files which are modified, staged or
+// untracked, relative to the root of the worktree and sorted.
+func (w *Worktree) Changes() ([]string, error) {
+	status, err := w.worktree.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]string, 0, len(status))
+	for path, fileStatus := range status {
+		if fileStatus.Staging != git.Unmodified || fileStatus.Worktree != git.Unmodified {
+			changes = append(changes, path)
+		}
+	}
+	sort.Strings(changes)
+
+	return changes, nil
+}
+
+// Staged returns the paths of the files which are staged for the next commit,
+// relative to the root of the worktree and sorted.
+func (w *Worktree) Staged() ([]string, error) {
+	status, err := w.worktree.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	staged := make([]string, 0, len(status))
+	for path, fileStatus := range status {
+		if fileStatus.Staging != git.Unmodified && fileStatus.Staging != git.Untracked {
+			staged = append(staged, path)
+		}
+	}
+	sort.Strings(staged)
+
+	return staged, nil
+}
+
+// CreateBranch checks out a new branch starting at the tip of base. An
+// existing branch of the same name is reset.
+func (w *Worktree) CreateBranch(branch, base string) error {
+	baseRef, err := w.repo.Reference(plumbing.NewBranchReferenceName(base), true)
+	if err != nil {
+		return fmt.Errorf("getting branch %s: %w", base, err)
+	}
+
+	name := plumbing.NewBranchReferenceName(branch)
+	if err := w.repo.Storer.RemoveReference(name); err != nil {
+		return err
+	}
+
+	return w.worktree.Checkout(&git.CheckoutOptions{
+		Hash:   baseRef.Hash(),
+		Branch: name,
+		Create: true,
+	})
+}
+
+// Checkout checks out an existing branch.
+func (w *Worktree) Checkout(branch string) error {
+	return w.worktree.Checkout(&git.CheckoutOptions{
+		Branch: plumbing.NewBranchReferenceName(branch),
+	})
+}
+
+// Commit stages exactly the given paths, relative to the root of the
+// worktree, and commits them along with anything already staged. The author
+// is taken from the UserName and Email of info if set, or else from the git
+// configuration. Returns the hash of the commit.
+func (w *Worktree) Commit(paths []string, message string, info *Info) (string, error) {
+	for _, path := range paths {
+		_, err := os.Lstat(filepath.Join(w.Root(), path))
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			_, err = w.worktree.Remove(path)
+		case err == nil:
+			_, err = w.worktree.Add(path)
+		}
+		if err != nil {
+			return "", fmt.Errorf("staging %s: %w", path, err)
+		}
+	}
+
+	options := &git.CommitOptions{}
+	if info != nil && info.UserName != "" && info.Email != "" {
+		options.Author = &object.Signature{
+			Name:  info.UserName,
+			Email: info.Email,
+			When:  time.Now(),
+		}
+	}
+
+	hash, err := w.worktree.Commit(message, options)
+	if err != nil {
+		return "", fmt.Errorf("committing: %w", err)
+	}
+
+	return hash.String(), nil
+}
+
+// RemoteBranchExists returns whether a branch exists on a remote.
+func (w *Worktree) RemoteBranchExists(remote, branch string, auth transport.AuthMethod) (bool, error) {
+	r, err := w.repo.Remote(remote)
+	if err != nil {
+		return false, fmt.Errorf("getting remote %s: %w", remote, err)
+	}
+
+	refs, err := r.List(&git.ListOptions{Auth: auth})
+	if errors.Is(err, transport.ErrEmptyRemoteRepository) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("listing branches of %s: %w", remote, err)
+	}
+
+	name := plumbing.NewBranchReferenceName(branch)
+	for _, ref := range refs {
+		if ref.Name() == name {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Push pushes a branch to a remote. It is never force-pushed, so commits
+// already on the remote branch cannot be overwritten.
+func (w *Worktree) Push(remote, branch string, auth transport.AuthMethod) error {
+	refSpec := config.RefSpec(fmt.Sprintf("refs/heads/%s:refs/heads/%s", branch, branch))
+
+	err := w.repo.Push(&git.PushOptions{
+		RemoteName: remote,
+		RefSpecs:   []config.RefSpec{refSpec},
+		Auth:       auth,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("pushing %s to %s: %w", branch, remote, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/require"
+)
+
+// newWorktree creates a repository with a commit on main, pushed to a bare
+// origin repository, and returns the paths of both.
+func newWorktree(t *testing.T) (work, origin string) {
+	t.Helper()
+
+	origin = t.TempDir()
+	_, err := git.PlainInit(origin, true)
+	require.NoError(t, err)
+
+	work = t.TempDir()
+	repo, err := git.PlainInitWithOptions(work, &git.PlainInitOptions{
+		InitOptions: git.InitOptions{DefaultBranch: plumbing.NewBranchReferenceName("main")},
+	})
+	require.NoError(t, err)
+	_, err = repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{origin}})
+	require.NoError(t, err)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(work, "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(work, "sub", "a.txt"), []byte("a"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(work, "b.txt"), []byte("b"), 0o644))
+
+	w, err := OpenWorktree(work)
+	require.NoError(t, err)
+	_, err = w.Commit([]string{"sub/a.txt", "b.txt"}, "Initial commit", &Info{UserName: "Honk", Email: "honk@example.com"})
+	require.NoError(t, err)
+	require.NoError(t, w.Push("origin", "main", nil))
+
+	return work, origin
+}
+
+func TestWorktree(t *testing.T) {
+	work, origin := newWorktree(t)
+
+	w, err := OpenWorktree(filepath.Join(work, "sub"))
+	require.NoError(t, err)
+	require.Equal(t, work, w.Root())
+
+	branch, err := w.Branch()
+	require.NoError(t, err)
+	require.Equal(t, "main", branch)
+
+	url, err := w.RemoteURL("origin")
+	require.NoError(t, err)
+	require.Equal(t, origin, url)
+
+	changes, err := w.Changes()
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	require.NoError(t, w.CreateBranch("update", "main"))
+	require.NoError(t, os.WriteFile(filepath.Join(work, "sub", "a.txt"), []byte("A"), 0o644))
+	require.NoError(t, os.Remove(filepath.Join(work, "b.txt")))
+	require.NoError(t, os.WriteFile(filepath.Join(work, "c.txt"), []byte("c"), 0o644))
+
+	changes, err = w.Changes()
+	require.NoError(t, err)
+	require.Equal(t, []string{"b.txt", "c.txt", "sub/a.txt"}, changes)
+	staged, err := w.Staged()
+	require.NoError(t, err)
+	require.Empty(t, staged)
+
+	// Only the given paths are committed
+	hash, err := w.Commit([]string{"b.txt", "sub/a.txt"}, "Update", &Info{UserName: "Goose", Email: "goose@example.com"})
+	require.NoError(t, err)
+	changes, err = w.Changes()
+	require.NoError(t, err)
+	require.Equal(t, []string{"c.txt"}, changes)
+	staged, err = w.Staged()
+	require.NoError(t, err)
+	require.Empty(t, staged)
+	require.NoError(t, os.Remove(filepath.Join(work, "c.txt")))
+
+	exists, err := w.RemoteBranchExists("origin", "update", nil)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	require.NoError(t, w.Push("origin", "update", nil))
+
+	exists, err = w.RemoteBranchExists("origin", "update", nil)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	remote, err := git.PlainOpen(origin)
+	require.NoError(t, err)
+	ref, err := remote.Reference(plumbing.NewBranchReferenceName("update"), true)
+	require.NoError(t, err)
+	require.Equal(t, hash, ref.Hash().String())
+
+	commit, err := remote.CommitObject(ref.Hash())
+	require.NoError(t, err)
+	require.Equal(t, "Update", commit.Message)
+	require.Equal(t, "Goose", commit.Author.Name)
+	require.Equal(t, "goose@example.com", commit.Author.Email)
+
+	// Back on main, the files are unchanged
+	require.NoError(t, w.Checkout("main"))
+	content, err := os.ReadFile(filepath.Join(work, "sub", "a.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "a", string(content))
+
+	// Recreating the branch resets it to its base
+	require.NoError(t, w.CreateBranch("update", "main"))
+	_, err = os.Stat(filepath.Join(work, "b.txt"))
+	require.NoError(t, err)
+
+	// Which does not overwrite the pushed branch
+	require.NoError(t, os.WriteFile(filepath.Join(work, "b.txt"), []byte("B"), 0o644))
+	_, err = w.Commit([]string{"b.txt"}, "Other update", &Info{UserName: "Goose", Email: "goose@example.com"})
+	require.NoError(t, err)
+	require.Error(t, w.Push("origin", "update", nil))
+	ref, err = remote.Reference(plumbing.NewBranchReferenceName("update"), true)
+	require.NoError(t, err)
+	require.Equal(t, hash, ref.Hash().String())
+
+	// Untracked files are not staged until added
+	require.NoError(t, os.WriteFile(filepath.Join(work, "d.txt"), []byte("d"), 0o644))
+	_, err = w.worktree.Add("d.txt")
+	require.NoError(t, err)
+	staged, err = w.Staged()
+	require.NoError(t, err)
+	require.Equal(t, []string{"d.txt"}, staged)
+}
+
+func TestOpenWorktreeError(t *testing.T) {
+	_, err := OpenWorktree(t.TempDir())
+	require.Error(t, err)
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gitea is a minimal client for the Gitea and Forgejo REST API.
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-utils/env"
+)
+
+const (
+	// TokenEnvKey is the default Gitea token environment variable key.
+	TokenEnvKey    = "GITEA_TOKEN"
+	apiVersionPath = "api/v1/"
+	pageSize       = 50
+)
+
+// Gitea is a wrapper around Gitea related functionality.
+type Gitea struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// Repository is the subset of a Gitea repository used by Zeitgeist.
+type Repository struct {
+	FullName string `json:"full_name"`
+	Archived bool   `json:"archived"`
+}
+
+// Release is the subset of a Gitea release used by Zeitgeist.
+type Release struct {
+	TagName     string    `json:"tag_name"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+	PublishedAt time.Time `json:"published_at"`
+	Body        string    `json:"body"`
+}
+
+// Tag is the subset of a Gitea tag used by Zeitgeist.
+type Tag struct {
+	Name   string `json:"name"`
+	Commit struct {
+		Created time.Time `json:"created"`
+	} `json:"commit"`
+}
+
+// Branch is the subset of a Gitea branch used by Zeitgeist.
+type Branch struct {
+	Name   string `json:"name"`
+	Commit struct {
+		ID string `json:"id"`
+	} `json:"commit"`
+}
+
+// New creates a new Gitea client for the given server, e.g. https://codeberg.org.
+// The token is read from the tokenEnv environment variable, or $GITEA_TOKEN if
+// tokenEnv is empty; an empty token results in unauthenticated requests.
+func New(server, tokenEnv string) *Gitea {
+	if tokenEnv == "" {
+		tokenEnv = TokenEnvKey
+	}
+
+	token := env.Default(tokenEnv, "")
+	if token == "" {
+		logrus.Debugf("No %s configured, using unauthenticated Gitea client", tokenEnv)
+	}
+
+	return &Gitea{
+		baseURL: strings.TrimSuffix(server, "/") + "/" + apiVersionPath,
+		token:   token,
+		client:  http.DefaultClient,
+	}
+}
+
+// GetRepository returns the Repository information for the provided `owner` and
+// `repo`.
+func (g *Gitea) GetRepository(owner, repo string) (*Repository, error) {
+	repository := &Repository{}
+	if err := g.get(fmt.Sprintf("repos/%s/%s", owner, repo), repository); err != nil {
+		return nil, fmt.Errorf("unable to retrieve Gitea repository %s/%s: %w", owner, repo, err)
+	}
+
+	return repository, nil
+}
+
+// Releases returns a list of Gitea releases for the provided `owner` and
+// `repo`.
+func (g *Gitea) Releases(owner, repo string) ([]*Release, error) {
+	var allReleases []*Release
+	for page := 1; ; page++ {
+		var releases []*Release
+		path := fmt.Sprintf("repos/%s/%s/releases?limit=%d&page=%d", owner, repo, pageSize, page)
+		if err := g.get(path, &releases); err != nil {
+			return nil, fmt.Errorf("unable to retrieve Gitea releases for %s/%s: %w", owner, repo, err)
+		}
+
+		allReleases = append(allReleases, releases...)
+		if len(releases) < pageSize {
+			return allReleases, nil
+		}
+	}
+}
+
+// ListTags returns a list of Gitea tags for the provided `owner` and
+// `repo`.
+func (g *Gitea) ListTags(owner, repo string) ([]*Tag, error) {
+	var allTags []*Tag
+	for page := 1; ; page++ {
+		var tags []*Tag
+		path := fmt.Sprintf("repos/%s/%s/tags?limit=%d&page=%d", owner, repo, pageSize, page)
+		if err := g.get(path, &tags); err != nil {
+			return nil, fmt.Errorf("unable to retrieve Gitea tags for %s/%s: %w", owner, repo, err)
+		}
+
+		allTags = append(allTags, tags...)
+		if len(tags) < pageSize {
+			return allTags, nil
+		}
+	}
+}
+
+// GetBranch returns the Branch information for the provided `owner`, `repo`
+// and `branch`.
+func (g *Gitea) GetBranch(owner, repo, branch string) (*Branch, error) {
+	b := &Branch{}
+	if err := g.get(fmt.Sprintf("repos/%s/%s/branches/%s", owner, repo, url.PathEscape(branch)), b); err != nil {
+		return nil, fmt.Errorf("unable to retrieve Gitea branch %s for %s/%s: %w", branch, owner, repo, err)
+	}
+
+	return b, nil
+}
+
+func (g *Gitea) get(path string, into interface{}) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, g.baseURL+path, http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if g.token != "" {
+		req.Header.Set("Authorization", "token "+g.token)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(into)
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/zeitgeist/pkg/gitea"
+)
+
+// newServer serves `total` tags for honk/honk, paginated.
+func newServer(t *testing.T, total int) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v1/repos/honk/honk/tags" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		page, err := strconv.Atoi(req.URL.Query().Get("page"))
+		require.NoError(t, err)
+		limit, err := strconv.Atoi(req.URL.Query().Get("limit"))
+		require.NoError(t, err)
+
+		tags := []*gitea.Tag{}
+		for i := (page - 1) * limit; i < page*limit && i < total; i++ {
+			tags = append(tags, &gitea.Tag{Name: fmt.Sprintf("v0.%d.0", i)})
+		}
+		require.NoError(t, json.NewEncoder(rw).Encode(tags))
+	}))
+}
+
+func TestListTagsPaginated(t *testing.T) {
+	for _, total := range []int{0, 3, 50, 120} {
+		server := newServer(t, total)
+
+		tags, err := gitea.New(server.URL, "").ListTags("honk", "honk")
+		require.NoError(t, err)
+		require.Len(t, tags, total)
+
+		server.Close()
+	}
+}
+
+func TestListTagsFailed(t *testing.T) {
+	server := newServer(t, 0)
+	defer server.Close()
+
+	_, err := gitea.New(server.URL, "").ListTags("honk", "doesnotexist")
+	require.Error(t, err)
+}
@@ -46,6 +46,12 @@ type gitlabClient struct {
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 //counterfeiter:generate . Client
 type Client interface {
+	CreateMergeRequest(
+		string, string, *gitlab.CreateMergeRequestOptions,
+	) (*gitlab.MergeRequest, *gitlab.Response, error)
+	ListMergeRequests(
+		string, string, *gitlab.ListProjectMergeRequestsOptions,
+	) ([]*gitlab.MergeRequest, *gitlab.Response, error)
 	ListProjects(
 		opt *gitlab.ListProjectsOptions,
 	) ([]*gitlab.Project, *gitlab.Response, error)
@@ -132,6 +138,20 @@ func (g *gitlabClient) ListTags(owner, repo string, opt *gitlab.ListTagsOptions,
 	return tags, resp, err
 }
 
+func (g *gitlabClient) ListMergeRequests(owner, repo string, opt *gitlab.ListProjectMergeRequestsOptions,
+) ([]*gitlab.MergeRequest, *gitlab.Response, error) {
+	project := fmt.Sprintf("%s/%s", owner, repo)
+	mergeRequests, resp, err := g.MergeRequests.ListProjectMergeRequests(project, opt)
+	return mergeRequests, resp, err
+}
+
+func (g *gitlabClient) CreateMergeRequest(owner, repo string, opt *gitlab.CreateMergeRequestOptions,
+) (*gitlab.MergeRequest, *gitlab.Response, error) {
+	project := fmt.Sprintf("%s/%s", owner, repo)
+	mergeRequest, resp, err := g.MergeRequests.CreateMergeRequest(project, opt)
+	return mergeRequest, resp, err
+}
+
 // SetClient can be used to manually set the internal GitLab client.
 func (g *GitLab) SetClient(client Client) {
 	g.client = client
@@ -198,3 +218,38 @@ func (g *GitLab) ListTags(owner, repo string) ([]*gitlab.Tag, error) {
 
 	return tags, nil
 }
+
+// OpenMergeRequests returns the open merge requests of the provided `owner`
+// and `repo` from the `source` branch to the `target` branch.
+func (g *GitLab) OpenMergeRequests(owner, repo, source, target string) ([]*gitlab.MergeRequest, error) {
+	opt := &gitlab.ListProjectMergeRequestsOptions{
+		State:        gitlab.Ptr("opened"),
+		SourceBranch: gitlab.Ptr(source),
+		TargetBranch: gitlab.Ptr(target),
+	}
+
+	mergeRequests, _, err := g.client.ListMergeRequests(owner, repo, opt)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve GitLab merge requests for %s/%s: %w", owner, repo, err)
+	}
+
+	return mergeRequests, nil
+}
+
+// CreateMergeRequest opens a merge request of the provided `owner` and `repo`
+// from the `source` branch to the `target` branch.
+func (g *GitLab) CreateMergeRequest(owner, repo, source, target, title, description string) (*gitlab.MergeRequest, error) {
+	opt := &gitlab.CreateMergeRequestOptions{
+		Title:        gitlab.Ptr(title),
+		Description:  gitlab.Ptr(description),
+		SourceBranch: gitlab.Ptr(source),
+		TargetBranch: gitlab.Ptr(target),
+	}
+
+	mergeRequest, _, err := g.client.CreateMergeRequest(owner, repo, opt)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create GitLab merge request for %s/%s: %w", owner, repo, err)
+	}
+
+	return mergeRequest, nil
+}
@@ -240,3 +240,57 @@ func TestListTags(t *testing.T) {
 	require.Equal(t, tag2, res[1].Name)
 	require.Equal(t, tag3, res[2].Name)
 }
+
+func TestOpenMergeRequests(t *testing.T) {
+	// Given
+	sut, client := newSUT()
+	client.ListMergeRequestsReturns([]*gogitlab.MergeRequest{
+		{IID: 3, WebURL: "https://gitlab.com/honkcorp/honk/-/merge_requests/3"},
+	}, nil, nil)
+
+	// When
+	res, err := sut.OpenMergeRequests("honkcorp", "honk", "zeitgeist/honk-1.0.0", "main")
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.Equal(t, 3, res[0].IID)
+
+	owner, repo, opt := client.ListMergeRequestsArgsForCall(0)
+	require.Equal(t, "honkcorp", owner)
+	require.Equal(t, "honk", repo)
+	require.Equal(t, "opened", *opt.State)
+	require.Equal(t, "zeitgeist/honk-1.0.0", *opt.SourceBranch)
+	require.Equal(t, "main", *opt.TargetBranch)
+}
+
+func TestOpenMergeRequestsFailed(t *testing.T) {
+	// Given
+	sut, client := newSUT()
+	client.ListMergeRequestsReturns(nil, nil, errors.New("error"))
+
+	// When
+	_, err := sut.OpenMergeRequests("honkcorp", "honk", "zeitgeist/honk-1.0.0", "main")
+
+	// Then
+	require.Error(t, err)
+}
+
+func TestCreateMergeRequest(t *testing.T) {
+	// Given
+	sut, client := newSUT()
+	client.CreateMergeRequestReturns(&gogitlab.MergeRequest{IID: 4}, nil, nil)
+
+	// When
+	res, err := sut.CreateMergeRequest("honkcorp", "honk", "zeitgeist/honk-1.0.0", "main", "Upgrade honk", "Honk!")
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, 4, res.IID)
+
+	_, _, opt := client.CreateMergeRequestArgsForCall(0)
+	require.Equal(t, "Upgrade honk", *opt.Title)
+	require.Equal(t, "Honk!", *opt.Description)
+	require.Equal(t, "zeitgeist/honk-1.0.0", *opt.SourceBranch)
+	require.Equal(t, "main", *opt.TargetBranch)
+}
@@ -9,6 +9,23 @@ import (
 )
 
 type FakeClient struct {
+	CreateMergeRequestStub        func(string, string, *gitlaba.CreateMergeRequestOptions) (*gitlaba.MergeRequest, *gitlaba.Response, error)
+	createMergeRequestMutex       sync.RWMutex
+	createMergeRequestArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *gitlaba.CreateMergeRequestOptions
+	}
+	createMergeRequestReturns struct {
+		result1 *gitlaba.MergeRequest
+		result2 *gitlaba.Response
+		result3 error
+	}
+	createMergeRequestReturnsOnCall map[int]struct {
+		result1 *gitlaba.MergeRequest
+		result2 *gitlaba.Response
+		result3 error
+	}
 	ListBranchesStub        func(string, string, *gitlaba.ListBranchesOptions) ([]*gitlaba.Branch, *gitlaba.Response, error)
 	listBranchesMutex       sync.RWMutex
 	listBranchesArgsForCall []struct {
@@ -26,6 +43,23 @@ type FakeClient struct {
 		result2 *gitlaba.Response
 		result3 error
 	}
+	ListMergeRequestsStub        func(string, string, *gitlaba.ListProjectMergeRequestsOptions) ([]*gitlaba.MergeRequest, *gitlaba.Response, error)
+	listMergeRequestsMutex       sync.RWMutex
+	listMergeRequestsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *gitlaba.ListProjectMergeRequestsOptions
+	}
+	listMergeRequestsReturns struct {
+		result1 []*gitlaba.MergeRequest
+		result2 *gitlaba.Response
+		result3 error
+	}
+	listMergeRequestsReturnsOnCall map[int]struct {
+		result1 []*gitlaba.MergeRequest
+		result2 *gitlaba.Response
+		result3 error
+	}
 	ListProjectsStub        func(*gitlaba.ListProjectsOptions) ([]*gitlaba.Project, *gitlaba.Response, error)
 	listProjectsMutex       sync.RWMutex
 	listProjectsArgsForCall []struct {
@@ -79,6 +113,75 @@ type FakeClient struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeClient) CreateMergeRequest(arg1 string, arg2 string, arg3 *gitlaba.CreateMergeRequestOptions) (*gitlaba.MergeRequest, *gitlaba.Response, error) {
+	fake.createMergeRequestMutex.Lock()
+	ret, specificReturn := fake.createMergeRequestReturnsOnCall[len(fake.createMergeRequestArgsForCall)]
+	fake.createMergeRequestArgsForCall = append(fake.createMergeRequestArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *gitlaba.CreateMergeRequestOptions
+	}{arg1, arg2, arg3})
+	stub := fake.CreateMergeRequestStub
+	fakeReturns := fake.createMergeRequestReturns
+	fake.recordInvocation("CreateMergeRequest", []interface{}{arg1, arg2, arg3})
+	fake.createMergeRequestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) CreateMergeRequestCallCount() int {
+	fake.createMergeRequestMutex.RLock()
+	defer fake.createMergeRequestMutex.RUnlock()
+	return len(fake.createMergeRequestArgsForCall)
+}
+
+func (fake *FakeClient) CreateMergeRequestCalls(stub func(string, string, *gitlaba.CreateMergeRequestOptions) (*gitlaba.MergeRequest, *gitlaba.Response, error)) {
+	fake.createMergeRequestMutex.Lock()
+	defer fake.createMergeRequestMutex.Unlock()
+	fake.CreateMergeRequestStub = stub
+}
+
+func (fake *FakeClient) CreateMergeRequestArgsForCall(i int) (string, string, *gitlaba.CreateMergeRequestOptions) {
+	fake.createMergeRequestMutex.RLock()
+	defer fake.createMergeRequestMutex.RUnlock()
+	argsForCall := fake.createMergeRequestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClient) CreateMergeRequestReturns(result1 *gitlaba.MergeRequest, result2 *gitlaba.Response, result3 error) {
+	fake.createMergeRequestMutex.Lock()
+	defer fake.createMergeRequestMutex.Unlock()
+	fake.CreateMergeRequestStub = nil
+	fake.createMergeRequestReturns = struct {
+		result1 *gitlaba.MergeRequest
+		result2 *gitlaba.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) CreateMergeRequestReturnsOnCall(i int, result1 *gitlaba.MergeRequest, result2 *gitlaba.Response, result3 error) {
+	fake.createMergeRequestMutex.Lock()
+	defer fake.createMergeRequestMutex.Unlock()
+	fake.CreateMergeRequestStub = nil
+	if fake.createMergeRequestReturnsOnCall == nil {
+		fake.createMergeRequestReturnsOnCall = make(map[int]struct {
+			result1 *gitlaba.MergeRequest
+			result2 *gitlaba.Response
+			result3 error
+		})
+	}
+	fake.createMergeRequestReturnsOnCall[i] = struct {
+		result1 *gitlaba.MergeRequest
+		result2 *gitlaba.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeClient) ListBranches(arg1 string, arg2 string, arg3 *gitlaba.ListBranchesOptions) ([]*gitlaba.Branch, *gitlaba.Response, error) {
 	fake.listBranchesMutex.Lock()
 	ret, specificReturn := fake.listBranchesReturnsOnCall[len(fake.listBranchesArgsForCall)]
@@ -148,6 +251,75 @@ func (fake *FakeClient) ListBranchesReturnsOnCall(i int, result1 []*gitlaba.Bran
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) ListMergeRequests(arg1 string, arg2 string, arg3 *gitlaba.ListProjectMergeRequestsOptions) ([]*gitlaba.MergeRequest, *gitlaba.Response, error) {
+	fake.listMergeRequestsMutex.Lock()
+	ret, specificReturn := fake.listMergeRequestsReturnsOnCall[len(fake.listMergeRequestsArgsForCall)]
+	fake.listMergeRequestsArgsForCall = append(fake.listMergeRequestsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *gitlaba.ListProjectMergeRequestsOptions
+	}{arg1, arg2, arg3})
+	stub := fake.ListMergeRequestsStub
+	fakeReturns := fake.listMergeRequestsReturns
+	fake.recordInvocation("ListMergeRequests", []interface{}{arg1, arg2, arg3})
+	fake.listMergeRequestsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) ListMergeRequestsCallCount() int {
+	fake.listMergeRequestsMutex.RLock()
+	defer fake.listMergeRequestsMutex.RUnlock()
+	return len(fake.listMergeRequestsArgsForCall)
+}
+
+func (fake *FakeClient) ListMergeRequestsCalls(stub func(string, string, *gitlaba.ListProjectMergeRequestsOptions) ([]*gitlaba.MergeRequest, *gitlaba.Response, error)) {
+	fake.listMergeRequestsMutex.Lock()
+	defer fake.listMergeRequestsMutex.Unlock()
+	fake.ListMergeRequestsStub = stub
+}
+
+func (fake *FakeClient) ListMergeRequestsArgsForCall(i int) (string, string, *gitlaba.ListProjectMergeRequestsOptions) {
+	fake.listMergeRequestsMutex.RLock()
+	defer fake.listMergeRequestsMutex.RUnlock()
+	argsForCall := fake.listMergeRequestsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClient) ListMergeRequestsReturns(result1 []*gitlaba.MergeRequest, result2 *gitlaba.Response, result3 error) {
+	fake.listMergeRequestsMutex.Lock()
+	defer fake.listMergeRequestsMutex.Unlock()
+	fake.ListMergeRequestsStub = nil
+	fake.listMergeRequestsReturns = struct {
+		result1 []*gitlaba.MergeRequest
+		result2 *gitlaba.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) ListMergeRequestsReturnsOnCall(i int, result1 []*gitlaba.MergeRequest, result2 *gitlaba.Response, result3 error) {
+	fake.listMergeRequestsMutex.Lock()
+	defer fake.listMergeRequestsMutex.Unlock()
+	fake.ListMergeRequestsStub = nil
+	if fake.listMergeRequestsReturnsOnCall == nil {
+		fake.listMergeRequestsReturnsOnCall = make(map[int]struct {
+			result1 []*gitlaba.MergeRequest
+			result2 *gitlaba.Response
+			result3 error
+		})
+	}
+	fake.listMergeRequestsReturnsOnCall[i] = struct {
+		result1 []*gitlaba.MergeRequest
+		result2 *gitlaba.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeClient) ListProjects(arg1 *gitlaba.ListProjectsOptions) ([]*gitlaba.Project, *gitlaba.Response, error) {
 	fake.listProjectsMutex.Lock()
 	ret, specificReturn := fake.listProjectsReturnsOnCall[len(fake.listProjectsArgsForCall)]
@@ -356,8 +528,12 @@ func (fake *FakeClient) ListTagsReturnsOnCall(i int, result1 []*gitlaba.Tag, res
 func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.createMergeRequestMutex.RLock()
+	defer fake.createMergeRequestMutex.RUnlock()
 	fake.listBranchesMutex.RLock()
 	defer fake.listBranchesMutex.RUnlock()
+	fake.listMergeRequestsMutex.RLock()
+	defer fake.listMergeRequestsMutex.RUnlock()
 	fake.listProjectsMutex.RLock()
 	defer fake.listProjectsMutex.RUnlock()
 	fake.listReleasesMutex.RLock()
@@ -0,0 +1,309 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package osv looks up vulnerabilities in the Open Source Vulnerabilities
+// database (https://osv.dev), either through its API or from an offline
+// export.
+package osv
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/blang/semver/v4"
+)
+
+// DefaultServer is the public OSV API server.
+const DefaultServer = "https://api.osv.dev"
+
+// Database holds any source of OSV advisories.
+type Database interface {
+	// Query returns the vulnerabilities affecting `version` of the package
+	// `name` in `ecosystem`, e.g. Go, PyPI or npm.
+	Query(ecosystem, name, version string) ([]*Vulnerability, error)
+}
+
+// Vulnerability is the subset of an OSV advisory used by Zeitgeist.
+type Vulnerability struct {
+	ID       string     `json:"id"`
+	Summary  string     `json:"summary,omitempty"`
+	Aliases  []string   `json:"aliases,omitempty"`
+	Affected []Affected `json:"affected,omitempty"`
+}
+
+// Affected describes the versions of a package affected by a vulnerability.
+type Affected struct {
+	Package  Package  `json:"package"`
+	Ranges   []Range  `json:"ranges,omitempty"`
+	Versions []string `json:"versions,omitempty"`
+}
+
+// Package identifies a package within an ecosystem.
+type Package struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+}
+
+// Range is a list of events delimiting affected versions.
+type Range struct {
+	Type   string  `json:"type"`
+	Events []Event `json:"events"`
+}
+
+// Event is a single range event, where only one field is set.
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+}
+
+// Affects returns whether `version` of the package `name` in `ecosystem` is
+// affected by the vulnerability, and if so the version fixing it, if any.
+//
+// Only SEMVER and ECOSYSTEM ranges whose versions follow semver are
+// evaluated, along with the explicit list of affected versions.
+func (v *Vulnerability) Affects(ecosystem, name, version string) (affected bool, fixed string) {
+	current, err := semver.ParseTolerant(version)
+	parsed := err == nil
+
+	for _, a := range v.Affected {
+		if a.Package.Ecosystem != ecosystem || a.Package.Name != name {
+			continue
+		}
+
+		if slices.Contains(a.Versions, version) {
+			affected = true
+		}
+
+		if !parsed {
+			continue
+		}
+
+		for _, r := range a.Ranges {
+			if r.Type != "SEMVER" && r.Type != "ECOSYSTEM" {
+				continue
+			}
+
+			inRange, fixedIn := r.contains(current)
+			if inRange {
+				affected = true
+				if fixedIn != "" {
+					fixed = fixedIn
+				}
+			}
+		}
+	}
+
+	return affected, fixed
+}
+
+// contains returns whether `version` falls within the range, and the version
+// closing that part of the range if it is fixed.
+func (r *Range) contains(version semver.Version) (bool, string) {
+	var introduced *semver.Version
+	for _, event := range r.Events {
+		switch {
+		case event.Introduced != "":
+			if event.Introduced == "0" {
+				introduced = &semver.Version{}
+				continue
+			}
+			v, err := semver.ParseTolerant(event.Introduced)
+			if err != nil {
+				return false, ""
+			}
+			introduced = &v
+		case event.Fixed != "":
+			v, err := semver.ParseTolerant(event.Fixed)
+			if err != nil {
+				return false, ""
+			}
+			if introduced != nil && version.GTE(*introduced) && version.LT(v) {
+				return true, event.Fixed
+			}
+			introduced = nil
+		case event.LastAffected != "":
+			v, err := semver.ParseTolerant(event.LastAffected)
+			if err != nil {
+				return false, ""
+			}
+			if introduced != nil && version.GTE(*introduced) && version.LTE(v) {
+				return true, ""
+			}
+			introduced = nil
+		}
+	}
+
+	return introduced != nil && version.GTE(*introduced), ""
+}
+
+// Client queries the OSV API.
+type Client struct {
+	server string
+	client *http.Client
+}
+
+// New creates a new client for the OSV API at `server`, or DefaultServer if
+// empty.
+func New(server string) *Client {
+	if server == "" {
+		server = DefaultServer
+	}
+
+	return &Client{
+		server: strings.TrimSuffix(server, "/"),
+		client: http.DefaultClient,
+	}
+}
+
+type queryRequest struct {
+	Version   string  `json:"version"`
+	Package   Package `json:"package"`
+	PageToken string  `json:"page_token,omitempty"`
+}
+
+type queryResponse struct {
+	Vulns         []*Vulnerability `json:"vulns"`
+	NextPageToken string           `json:"next_page_token"`
+}
+
+// Query returns the vulnerabilities affecting `version` of the package
+// `name` in `ecosystem`, as reported by the OSV API.
+func (c *Client) Query(ecosystem, name, version string) ([]*Vulnerability, error) {
+	query := queryRequest{
+		Version: version,
+		Package: Package{Ecosystem: ecosystem, Name: name},
+	}
+
+	var vulns []*Vulnerability
+	for {
+		var resp queryResponse
+		if err := c.post("/v1/query", query, &resp); err != nil {
+			return nil, fmt.Errorf("unable to query OSV for %s package %s %s: %w", ecosystem, name, version, err)
+		}
+
+		vulns = append(vulns, resp.Vulns...)
+		if resp.NextPageToken == "" {
+			return vulns, nil
+		}
+		query.PageToken = resp.NextPageToken
+	}
+}
+
+func (c *Client) post(path string, body, into interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, c.server+path, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(into)
+}
+
+// Offline is an OSV database loaded from a zip export, e.g.
+// https://osv-vulnerabilities.storage.googleapis.com/Go/all.zip, for use
+// without network access.
+type Offline struct {
+	// vulnerabilities indexed by ecosystem and package name
+	vulns map[Package][]*Vulnerability
+}
+
+// Open loads the advisories of the OSV zip export at `zipPath`.
+func Open(zipPath string) (*Offline, error) {
+	r, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return nil, fmt.Errorf("opening OSV database %s: %w", zipPath, err)
+	}
+	defer r.Close()
+
+	db := &Offline{vulns: map[Package][]*Vulnerability{}}
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() || path.Ext(f.Name) != ".json" {
+			continue
+		}
+
+		vuln, err := readVulnerability(f)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s from OSV database %s: %w", f.Name, zipPath, err)
+		}
+
+		seen := map[Package]bool{}
+		for _, a := range vuln.Affected {
+			if !seen[a.Package] {
+				seen[a.Package] = true
+				db.vulns[a.Package] = append(db.vulns[a.Package], vuln)
+			}
+		}
+	}
+
+	return db, nil
+}
+
+func readVulnerability(f *zip.File) (*Vulnerability, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+
+	vuln := &Vulnerability{}
+	if err := json.Unmarshal(b, vuln); err != nil {
+		return nil, err
+	}
+
+	return vuln, nil
+}
+
+// Query returns the vulnerabilities affecting `version` of the package
+// `name` in `ecosystem` in the offline database.
+func (o *Offline) Query(ecosystem, name, version string) ([]*Vulnerability, error) {
+	var vulns []*Vulnerability
+	for _, vuln := range o.vulns[Package{Ecosystem: ecosystem, Name: name}] {
+		if affected, _ := vuln.Affects(ecosystem, name, version); affected {
+			vulns = append(vulns, vuln)
+		}
+	}
+
+	return vulns, nil
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osv_test
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/zeitgeist/pkg/osv"
+)
+
+var honkVuln = &osv.Vulnerability{
+	ID:      "GHSA-honk-honk-honk",
+	Summary: "Honk injection",
+	Affected: []osv.Affected{{
+		Package: osv.Package{Ecosystem: "Go", Name: "example.com/honk"},
+		Ranges: []osv.Range{{
+			Type: "SEMVER",
+			Events: []osv.Event{
+				{Introduced: "0"},
+				{Fixed: "1.2.3"},
+				{Introduced: "1.3.0"},
+				{Fixed: "1.3.1"},
+			},
+		}},
+	}},
+}
+
+var goosVuln = &osv.Vulnerability{
+	ID: "GHSA-goos-goos-goos",
+	Affected: []osv.Affected{{
+		Package: osv.Package{Ecosystem: "Go", Name: "example.com/goose"},
+		Ranges: []osv.Range{{
+			Type:   "ECOSYSTEM",
+			Events: []osv.Event{{Introduced: "2.0.0"}, {LastAffected: "2.1.0"}},
+		}},
+		Versions: []string{"1.0.0-weird"},
+	}},
+}
+
+func TestAffects(t *testing.T) {
+	for _, tc := range []struct {
+		vuln     *osv.Vulnerability
+		pkg      string
+		version  string
+		affected bool
+		fixed    string
+	}{
+		{honkVuln, "example.com/honk", "1.0.0", true, "1.2.3"},
+		{honkVuln, "example.com/honk", "1.2.3", false, ""},
+		{honkVuln, "example.com/honk", "1.3.0", true, "1.3.1"},
+		{honkVuln, "example.com/honk", "1.4.0", false, ""},
+		{honkVuln, "example.com/other", "1.0.0", false, ""},
+		{goosVuln, "example.com/goose", "2.1.0", true, ""},
+		{goosVuln, "example.com/goose", "2.1.1", false, ""},
+		{goosVuln, "example.com/goose", "1.0.0-weird", true, ""},
+	} {
+		affected, fixed := tc.vuln.Affects("Go", tc.pkg, tc.version)
+		require.Equal(t, tc.affected, affected, "%s %s %s", tc.vuln.ID, tc.pkg, tc.version)
+		require.Equal(t, tc.fixed, fixed, "%s %s %s", tc.vuln.ID, tc.pkg, tc.version)
+	}
+}
+
+func TestQueryAPI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.URL.Path != "/v1/query" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		query := map[string]interface{}{}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&query))
+		require.Equal(t, "1.0.0", query["version"])
+
+		// Return one vulnerability per page
+		resp := map[string]interface{}{"vulns": []*osv.Vulnerability{honkVuln}, "next_page_token": "page-2"}
+		if query["page_token"] == "page-2" {
+			resp = map[string]interface{}{"vulns": []*osv.Vulnerability{goosVuln}}
+		}
+		require.NoError(t, json.NewEncoder(rw).Encode(resp))
+	}))
+	defer server.Close()
+
+	vulns, err := osv.New(server.URL+"/").Query("Go", "example.com/honk", "1.0.0")
+	require.NoError(t, err)
+	require.Len(t, vulns, 2)
+	require.Equal(t, "GHSA-honk-honk-honk", vulns[0].ID)
+	require.Equal(t, "GHSA-goos-goos-goos", vulns[1].ID)
+
+	_, err = osv.New(server.URL+"/nothing-here").Query("Go", "example.com/honk", "1.0.0")
+	require.Error(t, err)
+}
+
+func TestQueryOffline(t *testing.T) {
+	zipPath := filepath.Join(t.TempDir(), "all.zip")
+	f, err := os.Create(zipPath)
+	require.NoError(t, err)
+
+	w := zip.NewWriter(f)
+	for _, vuln := range []*osv.Vulnerability{honkVuln, goosVuln} {
+		entry, err := w.Create(vuln.ID + ".json")
+		require.NoError(t, err)
+		require.NoError(t, json.NewEncoder(entry).Encode(vuln))
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	db, err := osv.Open(zipPath)
+	require.NoError(t, err)
+
+	vulns, err := db.Query("Go", "example.com/honk", "1.3.0")
+	require.NoError(t, err)
+	require.Len(t, vulns, 1)
+	require.Equal(t, "GHSA-honk-honk-honk", vulns[0].ID)
+
+	vulns, err = db.Query("Go", "example.com/honk", "1.3.1")
+	require.NoError(t, err)
+	require.Empty(t, vulns)
+
+	vulns, err = db.Query("PyPI", "example.com/honk", "1.0.0")
+	require.NoError(t, err)
+	require.Empty(t, vulns)
+
+	_, err = osv.Open(filepath.Join(t.TempDir(), "does-not-exist.zip"))
+	require.Error(t, err)
+}
@@ -19,11 +19,14 @@ package dependency
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/mitchellh/mapstructure"
@@ -38,23 +41,91 @@ func init() {
 }
 
 type RemoteClient struct {
-	LocalClient  deppkg.Client
+	LocalClient deppkg.Client
+
+	// Options are the optional settings of the client
+	Options deppkg.ClientOptions
+
+	// AWSEC2Client is used by AMI upstreams if set, instead of a client
+	// created from their AWS options
 	AWSEC2Client EC2DescribeImagesAPI
+
+	// AWSSSMClient is used by SSM upstreams if set, instead of a client
+	// created from their AWS options
+	AWSSSMClient upstream.SSMGetParametersAPI
+
+	// ec2Clients caches the AWS clients created for AMI upstreams
+	ec2Clients map[upstream.AWSOptions]EC2DescribeImagesAPI
+
+	// ssmClients caches the AWS clients created for SSM upstreams
+	ssmClients map[upstream.AWSOptions]upstream.SSMGetParametersAPI
 }
 
 type EC2DescribeImagesAPI interface {
 	DescribeImages(ctx context.Context, params *ec2.DescribeImagesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeImagesOutput, error)
 }
 
-func NewRemoteClient() (deppkg.Client, error) {
-	localClient, err := deppkg.NewLocalClient()
+func NewRemoteClient(opts ...deppkg.ClientOption) (deppkg.Client, error) {
+	localClient, err := deppkg.NewLocalClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &RemoteClient{
+		LocalClient: localClient,
+	}
+	for _, opt := range opts {
+		opt(&client.Options)
+	}
+	return client, nil
+}
+
+// ec2Client returns the AWS client to use for the given options, only
+// creating it when an AMI upstream needs it.
+func (c *RemoteClient) ec2Client(options upstream.AWSOptions) (EC2DescribeImagesAPI, error) {
+	if c.AWSEC2Client != nil {
+		return c.AWSEC2Client, nil
+	}
+
+	if client, ok := c.ec2Clients[options]; ok {
+		return client, nil
+	}
+
+	client, err := upstream.NewAWSClient(options)
 	if err != nil {
 		return nil, err
 	}
-	return &RemoteClient{
-		LocalClient:  localClient,
-		AWSEC2Client: upstream.NewAWSClient(),
-	}, nil
+
+	if c.ec2Clients == nil {
+		c.ec2Clients = map[upstream.AWSOptions]EC2DescribeImagesAPI{}
+	}
+	c.ec2Clients[options] = client
+
+	return client, nil
+}
+
+// ssmClient returns the AWS client to use for the given options, only
+// creating it when an SSM upstream needs it.
+func (c *RemoteClient) ssmClient(options upstream.AWSOptions) (upstream.SSMGetParametersAPI, error) {
+	if c.AWSSSMClient != nil {
+		return c.AWSSSMClient, nil
+	}
+
+	if client, ok := c.ssmClients[options]; ok {
+		return client, nil
+	}
+
+	client, err := upstream.NewSSMClient(options)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ssmClients == nil {
+		c.ssmClients = map[upstream.AWSOptions]upstream.SSMGetParametersAPI{}
+	}
+	c.ssmClients[options] = client
+
+	return client, nil
 }
 
 func (c *RemoteClient) LocalCheck(dependencyFilePath, basePath string) error {
@@ -63,7 +134,9 @@ func (c *RemoteClient) LocalCheck(dependencyFilePath, basePath string) error {
 
 // RemoteCheck checks whether dependencies are up to date with upstream
 //
-// Will return an error if checking the versions upstream fails.
+// Will return an error if checking the versions upstream fails, or along with
+// the updates if a dependency lags further behind upstream than its maxBehind
+// or maxAge.
 //
 // Out-of-date dependencies will be printed out on stdout at the INFO level.
 func (c *RemoteClient) RemoteCheck(dependencyFilePath string) ([]string, error) {
@@ -79,8 +152,22 @@ func (c *RemoteClient) RemoteCheck(dependencyFilePath string) ([]string, error)
 		return nil, err
 	}
 
+	var lagging []error
 	for _, vu := range versionUpdateInfos {
-		if vu.UpdateAvailable {
+		dependency, err := findDependencyByName(externalDeps.Dependencies, vu.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		// Held dependencies are expected to lag behind
+		if vu.Hold == nil {
+			if err := dependency.CheckStaleness(vu.Staleness); err != nil {
+				lagging = append(lagging, err)
+			}
+		}
+
+		switch {
+		case vu.UpdateAvailable:
 			updates = append(
 				updates,
 				fmt.Sprintf(
@@ -90,7 +177,18 @@ func (c *RemoteClient) RemoteCheck(dependencyFilePath string) ([]string, error)
 					vu.Current.Version,
 				),
 			)
-		} else {
+		case vu.Hold != nil:
+			updates = append(
+				updates,
+				fmt.Sprintf(
+					"Dependency %s is %s (current: %s, latest: %s)",
+					vu.Name,
+					vu.Hold,
+					vu.Current.Version,
+					vu.Latest.Version,
+				),
+			)
+		default:
 			log.Debugf(
 				"No update available for dependency %s: %s (latest: %s)\n",
 				vu.Name,
@@ -100,6 +198,10 @@ func (c *RemoteClient) RemoteCheck(dependencyFilePath string) ([]string, error)
 		}
 	}
 
+	if len(lagging) > 0 {
+		return updates, fmt.Errorf("dependencies lag too far behind upstream: %w", errors.Join(lagging...))
+	}
+
 	return updates, nil
 }
 
@@ -119,46 +221,108 @@ func (c *RemoteClient) Upgrade(dependencyFilePath, basePath string) ([]string, e
 	}
 
 	upgrades := make([]string, 0)
-	upgradedDependencies := make([]*deppkg.Dependency, 0)
 
 	versionUpdateInfos, err := c.CheckUpstreamVersions(externalDeps.Dependencies)
 	if err != nil {
 		return nil, err
 	}
 
+	// The dependencies file is written with the checked dependencies, which
+	// are upgraded in place
+	upgradedDependencies := make([]*deppkg.Dependency, 0, len(versionUpdateInfos))
 	for _, vu := range versionUpdateInfos {
 		dependency, err := findDependencyByName(externalDeps.Dependencies, vu.Name)
 		if err != nil {
 			return nil, err
 		}
+		upgradedDependencies = append(upgradedDependencies, dependency)
+	}
+	writeDependencies := func() error {
+		return deppkg.ToFile(dependencyFilePath, &deppkg.Dependencies{
+			Dependencies: upgradedDependencies,
+			Rules:        externalDeps.Rules,
+		})
+	}
 
-		if vu.UpdateAvailable {
-			err = upgradeDependency(basePath, dependency, &vu)
-			if err != nil {
+	refused := refusedUpgrades(externalDeps, versionUpdateInfos)
+
+	// Upgrades are planned, and checked by BeforeUpgrade, before any file is
+	// written
+	type plannedUpgrade struct {
+		upgrade  *deppkg.Upgrade
+		upgraded map[string]string
+	}
+	planned := map[string]*plannedUpgrade{}
+	contents := map[string]string{}
+	for i, vu := range versionUpdateInfos {
+		if _, ok := refused[vu.Name]; ok || !vu.UpdateAvailable {
+			continue
+		}
+
+		files, upgraded, err := upgradeDependency(basePath, upgradedDependencies[i], &vu, contents)
+		if err != nil {
+			return nil, err
+		}
+
+		upgrade := &deppkg.Upgrade{
+			Name:    vu.Name,
+			Current: vu.Current.Version,
+			Latest:  vu.Latest.Version,
+			Files:   append(files, dependencyFilePath),
+		}
+		if c.Options.BeforeUpgrade != nil {
+			if err := c.Options.BeforeUpgrade(upgrade); err != nil {
 				return nil, err
 			}
+		}
+		planned[vu.Name] = &plannedUpgrade{upgrade: upgrade, upgraded: upgraded}
+	}
 
-			dependency.Version = vu.Latest.Version
-			upgradedDependencies = append(
-				upgradedDependencies,
-				dependency,
-			)
+	for i, vu := range versionUpdateInfos {
+		dependency := upgradedDependencies[i]
 
+		if reason, ok := refused[vu.Name]; ok {
 			upgrades = append(
 				upgrades,
 				fmt.Sprintf(
-					"Upgraded dependency %s from version %s to version %s",
+					"Refused to upgrade dependency %s from version %s to version %s: %v",
 					vu.Name,
 					vu.Current.Version,
 					vu.Latest.Version,
+					reason,
 				),
 			)
-		} else {
-			upgradedDependencies = append(
-				upgradedDependencies,
-				dependency,
-			)
+			continue
+		}
 
+		if vu.UpdateAvailable {
+			if err := writeFiles(planned[vu.Name].upgraded); err != nil {
+				return nil, err
+			}
+
+			dependency.Version = vu.Latest.Version
+
+			if c.Options.OnUpgrade != nil {
+				if err := writeDependencies(); err != nil {
+					return nil, err
+				}
+
+				if err := c.Options.OnUpgrade(planned[vu.Name].upgrade); err != nil {
+					return nil, err
+				}
+			}
+
+			upgrade := fmt.Sprintf(
+				"Upgraded dependency %s from version %s to version %s",
+				vu.Name,
+				vu.Current.Version,
+				vu.Latest.Version,
+			)
+			if changelog := deppkg.RenderChangelog(vu.Name, vu.Current.Version, vu.Latest.Version, vu.ReleaseNotes); changelog != "" {
+				upgrade += "\n\n" + changelog
+			}
+			upgrades = append(upgrades, upgrade)
+		} else {
 			log.Debugf(
 				"No update available for dependency %s: %s (latest: %s)\n",
 				vu.Name,
@@ -169,16 +333,90 @@ func (c *RemoteClient) Upgrade(dependencyFilePath, basePath string) ([]string, e
 	}
 
 	// Update the dependencies file to reflect the upgrades
-	err = deppkg.ToFile(dependencyFilePath, &deppkg.Dependencies{
-		Dependencies: upgradedDependencies,
-	})
-	if err != nil {
+	if err := writeDependencies(); err != nil {
 		return nil, err
 	}
 
 	return upgrades, nil
 }
 
+// refusedUpgrades returns the available upgrades which would break a version
+// skew rule, along with the broken rule. Upgrades are checked together, so
+// that dependencies bound by a rule can be upgraded in lockstep. For each
+// broken rule, the upgrade of the constrained dependency is refused first if
+// that is enough to satisfy the rule, then the one of the dependency it is
+// relative to, or else both.
+func refusedUpgrades(externalDeps *deppkg.Dependencies, versionUpdateInfos []deppkg.VersionUpdateInfo) map[string]error {
+	proposed := externalDeps.Versions()
+	current := externalDeps.Versions()
+	latest := map[string]string{}
+	for _, vu := range versionUpdateInfos {
+		if vu.UpdateAvailable {
+			proposed[vu.Name] = vu.Latest.Version
+			latest[vu.Name] = vu.Latest.Version
+		}
+	}
+
+	refused := map[string]error{}
+	refuse := func(name string, reason error) {
+		log.Debugf("Refusing to upgrade dependency %s to version %s: %v", name, latest[name], reason)
+		refused[name] = reason
+		proposed[name] = current[name]
+	}
+
+	for _, rule := range externalDeps.Rules {
+		err := rule.Check(proposed)
+		if err == nil {
+			continue
+		}
+
+		var candidates []string
+		for _, name := range []string{rule.Dependency, rule.RelativeTo} {
+			if _, pending := latest[name]; pending && refused[name] == nil {
+				candidates = append(candidates, name)
+			}
+		}
+
+		fixed := false
+		for _, name := range candidates {
+			proposed[name] = current[name]
+			if rule.Check(proposed) == nil {
+				refuse(name, err)
+				fixed = true
+				break
+			}
+			proposed[name] = latest[name]
+		}
+
+		if !fixed {
+			for _, name := range candidates {
+				refuse(name, err)
+			}
+		}
+	}
+
+	// Refusing upgrades can break rules which were satisfied with them, so
+	// refuse the remaining upgrades bound by broken rules
+	for changed := true; changed; {
+		changed = false
+		for _, rule := range externalDeps.Rules {
+			err := rule.Check(proposed)
+			if err == nil {
+				continue
+			}
+
+			for _, name := range []string{rule.Dependency, rule.RelativeTo} {
+				if _, pending := latest[name]; pending && refused[name] == nil {
+					refuse(name, err)
+					changed = true
+				}
+			}
+		}
+	}
+
+	return refused
+}
+
 func findDependencyByName(dependencies []*deppkg.Dependency, name string) (*deppkg.Dependency, error) {
 	for _, dep := range dependencies {
 		if dep.Name == name {
@@ -188,91 +426,126 @@ func findDependencyByName(dependencies []*deppkg.Dependency, name string) (*depp
 	return nil, fmt.Errorf("cannot find dependency by name: %s", name)
 }
 
-func upgradeDependency(basePath string, dependency *deppkg.Dependency, versionUpdate *deppkg.VersionUpdateInfo) error {
+// upgradeDependency replaces the current version with the latest one in the
+// refPaths of the dependency, without writing them. Files are read from
+// `contents` if already there, e.g. upgraded by another dependency, and
+// stored into it. Returns the files which changed, along with their
+// upgraded contents.
+func upgradeDependency(
+	basePath string, dependency *deppkg.Dependency, versionUpdate *deppkg.VersionUpdateInfo, contents map[string]string,
+) ([]string, map[string]string, error) {
 	log.Debugf("running upgradeDependency, versionUpdate %#v", versionUpdate)
+	var files []string
+	upgraded := map[string]string{}
 	for _, refPath := range dependency.RefPaths {
-		err := replaceInFile(basePath, refPath, versionUpdate)
+		filename := filepath.Join(basePath, refPath.Path)
+		content, ok := contents[filename]
+		if !ok {
+			inputFile, err := os.ReadFile(filename)
+			if err != nil {
+				return nil, nil, fmt.Errorf("reading file: %w", err)
+			}
+			content = string(inputFile)
+		}
+
+		upgradedContent, err := replaceInContent(content, refPath, versionUpdate)
 		if err != nil {
-			return err
+			return nil, nil, err
+		}
+		contents[filename] = upgradedContent
+
+		if upgradedContent != content {
+			upgraded[filename] = upgradedContent
+			if !slices.Contains(files, filename) {
+				files = append(files, filename)
+			}
 		}
 	}
 
-	return nil
+	return files, upgraded, nil
 }
 
-func replaceInFile(basePath string, refPath *deppkg.RefPath, versionUpdate *deppkg.VersionUpdateInfo) error {
-	filename := filepath.Join(basePath, refPath.Path)
-	log.Debugf("running replaceInFile, refpath is %#v, versionUpdate %#v", refPath, versionUpdate)
+// replaceInContent replaces the current version with the latest one in the
+// lines of the content of a refPath.
+func replaceInContent(content string, refPath *deppkg.RefPath, versionUpdate *deppkg.VersionUpdateInfo) (string, error) {
+	log.Debugf("running replaceInContent, refpath is %#v, versionUpdate %#v", refPath, versionUpdate)
 
 	matcher, err := regexp.Compile(refPath.Match)
 	if err != nil {
-		return fmt.Errorf("compiling regex: %w", err)
+		return "", fmt.Errorf("compiling regex: %w", err)
+	}
+
+	currentVersion, err := refPath.VersionFor(versionUpdate.Current.Version)
+	if err != nil {
+		return "", err
 	}
 
-	inputFile, err := os.ReadFile(filename)
+	latestVersion, err := refPath.VersionFor(versionUpdate.Latest.Version)
 	if err != nil {
-		return fmt.Errorf("reading file: %w", err)
+		return "", err
 	}
 
-	lines := strings.Split(string(inputFile), "\n")
+	lines := strings.Split(content, "\n")
 
 	for i, line := range lines {
 		if matcher.MatchString(line) {
-			if strings.Contains(line, versionUpdate.Current.Version) {
+			if strings.Contains(line, currentVersion) {
 				log.Debugf(
 					"Line %d matches expected regexp %q and version %q: %s",
 					i,
 					refPath.Match,
-					versionUpdate.Current.Version,
+					currentVersion,
 					line,
 				)
 
 				// The actual upgrade:
-				lines[i] = strings.ReplaceAll(line, versionUpdate.Current.Version, versionUpdate.Latest.Version)
+				lines[i] = strings.ReplaceAll(line, currentVersion, latestVersion)
 			}
 		}
 	}
 
-	upgradedFile := strings.Join(lines, "\n")
+	return strings.Join(lines, "\n"), nil
+}
 
-	// Finally, write the file out
-	err = os.WriteFile(filename, []byte(upgradedFile), 0o644)
-	if err != nil {
-		return fmt.Errorf("writing file: %w", err)
+// writeFiles writes out the upgraded contents of files.
+func writeFiles(contents map[string]string) error {
+	for filename, content := range contents {
+		if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+			return fmt.Errorf("writing file: %w", err)
+		}
 	}
+
 	return nil
 }
 
-func (c *RemoteClient) RemoteExport(dependencyFilePath string) ([]deppkg.VersionUpdate, error) {
+func (c *RemoteClient) RemoteExport(dependencyFilePath string) (*deppkg.Export, error) {
 	externalDeps, err := deppkg.FromFile(dependencyFilePath)
 	if err != nil {
 		return nil, err
 	}
 
-	versionUpdates := []deppkg.VersionUpdate{}
-
 	versionUpdatesInfos, err := c.CheckUpstreamVersions(externalDeps.Dependencies)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, vui := range versionUpdatesInfos {
-		if vui.UpdateAvailable {
-			versionUpdates = append(versionUpdates, deppkg.VersionUpdate{
-				Name:       vui.Name,
-				Version:    vui.Current.Version,
-				NewVersion: vui.Latest.Version,
-			})
-		} else {
-			log.Debugf(
-				"No update available for dependency %s: %s (latest: %s)\n",
-				vui.Name,
-				vui.Current.Version,
-				vui.Latest.Version,
-			)
-		}
+	return deppkg.NewExport(versionUpdatesInfos), nil
+}
+
+// decodeUpstream decodes the upstream configuration into a concrete upstream.
+//
+// Upstream values are all strings in the configuration file, so they are
+// weakly decoded, e.g. "true" into a bool.
+func decodeUpstream(up map[string]string, result interface{}) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           result,
+	})
+	if err != nil {
+		return err
 	}
-	return versionUpdates, nil
+
+	return decoder.Decode(up)
 }
 
 func (c *RemoteClient) CheckUpstreamVersions(deps []*deppkg.Dependency) ([]deppkg.VersionUpdateInfo, error) {
@@ -286,77 +559,152 @@ func (c *RemoteClient) CheckUpstreamVersions(deps []*deppkg.Dependency) ([]deppk
 		latestVersion := deppkg.Version{Version: dep.Version, Scheme: dep.Scheme}
 		currentVersion := deppkg.Version{Version: dep.Version, Scheme: dep.Scheme}
 
-		var err error
+		var (
+			err     error
+			skipped []upstream.SkippedVersion
+		)
 
 		// Cast the flavour from the currently unknown upstream type
 		flavour := upstream.Flavour(up["flavour"])
+		minAge, err := deppkg.ParseAge(dep.MinAge)
+		if err != nil {
+			return nil, fmt.Errorf("dependency %s: %w", dep.Name, err)
+		}
+
+		// Settings of the dependency shared by all flavours
+		base := upstream.Base{
+			Flavour:        flavour,
+			Prereleases:    upstream.PrereleasePolicy(dep.Prereleases),
+			CurrentVersion: dep.Version,
+			MinAge:         minAge,
+			IgnoreVersions: dep.IgnoreVersions,
+			History:        upstream.NewHistory(dep.Version, up["constraints"]),
+		}
+		if c.Options.Changelog {
+			base.ReleaseNotes = upstream.NewReleaseNotes()
+		}
 		switch flavour {
 		case upstream.DummyFlavour:
 			var d upstream.Dummy
 
-			decodeErr := mapstructure.Decode(up, &d)
+			decodeErr := decodeUpstream(up, &d)
 			if decodeErr != nil {
 				return nil, decodeErr
 			}
+			d.Base = base
 
 			latestVersion.Version, err = d.LatestVersion()
 		case upstream.GithubFlavour:
 			var gh upstream.Github
 
-			decodeErr := mapstructure.Decode(up, &gh)
+			decodeErr := decodeUpstream(up, &gh)
 			if decodeErr != nil {
 				return nil, decodeErr
 			}
+			gh.Base = base
 
 			latestVersion.Version, err = gh.LatestVersion()
 		case upstream.GitLabFlavour:
 			var gl upstream.GitLab
 
-			decodeErr := mapstructure.Decode(up, &gl)
+			decodeErr := decodeUpstream(up, &gl)
 			if decodeErr != nil {
 				return nil, decodeErr
 			}
+			gl.Base = base
 
 			latestVersion.Version, err = gl.LatestVersion()
+		case upstream.GiteaFlavour, upstream.ForgejoFlavour:
+			var gt upstream.Gitea
+
+			decodeErr := decodeUpstream(up, &gt)
+			if decodeErr != nil {
+				return nil, decodeErr
+			}
+			gt.Base = base
+
+			latestVersion.Version, err = gt.LatestVersion()
+		case upstream.BitbucketFlavour:
+			var bb upstream.Bitbucket
+
+			decodeErr := decodeUpstream(up, &bb)
+			if decodeErr != nil {
+				return nil, decodeErr
+			}
+			bb.Base = base
+
+			latestVersion.Version, err = bb.LatestVersion()
 		case upstream.HelmFlavour:
 			var h upstream.Helm
 
-			decodeErr := mapstructure.Decode(up, &h)
+			decodeErr := decodeUpstream(up, &h)
 			if decodeErr != nil {
 				return nil, decodeErr
 			}
+			h.Base = base
 
 			latestVersion.Version, err = h.LatestVersion()
 		case upstream.AMIFlavour:
 			var ami upstream.AMI
 
-			decodeErr := mapstructure.Decode(up, &ami)
+			decodeErr := decodeUpstream(up, &ami)
 			if decodeErr != nil {
 				return nil, decodeErr
 			}
+			ami.Base = base
 
-			ami.ServiceClient = c.AWSEC2Client
+			ami.ServiceClient, err = c.ec2Client(ami.AWSOptions)
+			if err != nil {
+				return nil, fmt.Errorf("dependency %s: %w", dep.Name, err)
+			}
 
 			latestVersion.Version, err = ami.LatestVersion()
+		case upstream.SSMFlavour:
+			var ssm upstream.SSM
+
+			decodeErr := decodeUpstream(up, &ssm)
+			if decodeErr != nil {
+				return nil, decodeErr
+			}
+			ssm.Base = base
+
+			ssm.ServiceClient, err = c.ssmClient(ssm.AWSOptions)
+			if err != nil {
+				return nil, fmt.Errorf("dependency %s: %w", dep.Name, err)
+			}
+
+			latestVersion.Version, err = ssm.LatestVersion()
 		case upstream.ContainerFlavour:
 			var ct upstream.Container
 
-			decodeErr := mapstructure.Decode(up, &ct)
+			decodeErr := decodeUpstream(up, &ct)
 			if decodeErr != nil {
 				log.Debug("errr decoding")
 				return nil, decodeErr
 			}
+			ct.Base = base
 
-			latestVersion.Version, err = ct.LatestVersion()
+			latestVersion.Version, skipped, err = ct.LatestVersionWithSkipped()
 		case upstream.EKSFlavour:
 			var eks upstream.EKS
 
-			decodeErr := mapstructure.Decode(up, &eks)
+			decodeErr := decodeUpstream(up, &eks)
 			if decodeErr != nil {
 				return nil, decodeErr
 			}
+			eks.Base = base
 
 			latestVersion.Version, err = eks.LatestVersion()
+		case upstream.HTTPFlavour:
+			var h upstream.HTTP
+
+			decodeErr := decodeUpstream(up, &h)
+			if decodeErr != nil {
+				return nil, decodeErr
+			}
+			h.Base = base
+
+			latestVersion.Version, err = h.LatestVersion()
 		default:
 			return nil, fmt.Errorf("unknown upstream flavour '%#v' for dependency %s", flavour, dep.Name)
 		}
@@ -365,17 +713,80 @@ func (c *RemoteClient) CheckUpstreamVersions(deps []*deppkg.Dependency) ([]deppk
 			return nil, fmt.Errorf("dependency %s: %w", dep.Name, err)
 		}
 
+		// Upstreams returning a single version cannot skip ignored ones
+		if latestVersion.Version != currentVersion.Version && base.Ignores(latestVersion.Version) {
+			log.Debugf("Ignoring version %s of dependency %s", latestVersion.Version, dep.Name)
+			skipped = append(skipped, upstream.SkippedVersion{Version: latestVersion.Version, Reason: "ignored"})
+			latestVersion.Version = currentVersion.Version
+		}
+
 		updateAvailable, err := latestVersion.MoreSensitivelyRecentThan(currentVersion, dep.Sensitivity)
 		if err != nil {
 			return nil, fmt.Errorf("comparing dependency %s: %w", dep.Name, err)
 		}
 
-		versionUpdates = append(versionUpdates, deppkg.VersionUpdateInfo{
+		currentTag, currentDigest := deppkg.SplitDigest(currentVersion.Version)
+		latestTag, latestDigest := deppkg.SplitDigest(latestVersion.Version)
+		if currentTag == latestTag && currentDigest != "" && latestDigest != "" && currentDigest != latestDigest {
+			log.Warnf(
+				"Dependency %s: %s was re-published with a new digest (current: %s, latest: %s)",
+				dep.Name,
+				currentTag,
+				currentDigest,
+				latestDigest,
+			)
+		}
+
+		vui := deppkg.VersionUpdateInfo{
 			Name:            dep.Name,
 			Current:         currentVersion,
 			Latest:          latestVersion,
 			UpdateAvailable: updateAvailable,
-		})
+		}
+		for _, s := range skipped {
+			vui.Skipped = append(vui.Skipped, deppkg.SkippedVersion{Version: s.Version, Reason: s.Reason})
+		}
+
+		if updateAvailable {
+			for _, note := range base.ReleaseNotes.Between(currentVersion.Version, latestVersion.Version) {
+				vui.ReleaseNotes = append(vui.ReleaseNotes, deppkg.ReleaseNote{Version: note.Version, Notes: note.Notes})
+			}
+		}
+
+		if staleness := base.History.Staleness(); staleness != nil {
+			vui.Staleness = &deppkg.Staleness{
+				NewerReleases: staleness.NewerReleases,
+				MajorsBehind:  staleness.MajorsBehind,
+				MinorsBehind:  staleness.MinorsBehind,
+				PatchesBehind: staleness.PatchesBehind,
+			}
+			if !staleness.SupersededAt.IsZero() {
+				vui.Staleness.SupersededAt = &staleness.SupersededAt
+				vui.Staleness.DaysBehind = int(time.Since(staleness.SupersededAt).Hours() / 24)
+			}
+		}
+
+		if dep.Hold != nil {
+			held, err := dep.Hold.Active(time.Now())
+			if err != nil {
+				return nil, fmt.Errorf("dependency %s: %w", dep.Name, err)
+			}
+
+			if held {
+				vui.Hold = dep.Hold
+				if vui.UpdateAvailable {
+					vui.UpdateAvailable = false
+					vui.Skipped = append(vui.Skipped, deppkg.SkippedVersion{
+						Version: latestVersion.Version,
+						Reason:  dep.Hold.String(),
+					})
+				}
+			} else {
+				log.Warnf("Hold on dependency %s expired on %s (%s), it can be removed", dep.Name, dep.Hold.Until, dep.Hold.Reason)
+			}
+		}
+
+		versionUpdates = append(versionUpdates, vui)
 	}
 
 	return versionUpdates, nil
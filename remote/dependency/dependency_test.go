@@ -18,14 +18,21 @@ package dependency
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
 	"github.com/stretchr/testify/require"
 
 	deppkg "sigs.k8s.io/zeitgeist/dependency"
@@ -40,6 +47,28 @@ func (m mockedEc2DescribeImagesAPI) DescribeImages(_ context.Context, _ *ec2.Des
 	return &m.Resp, nil
 }
 
+type mockedSSMGetParametersAPI struct {
+	Value    string
+	Names    []string
+	Modified map[string]time.Time
+}
+
+func (m mockedSSMGetParametersAPI) GetParameter(_ context.Context, params *ssm.GetParameterInput, _ ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+	return &ssm.GetParameterOutput{Parameter: &ssmtypes.Parameter{Name: params.Name, Value: aws.String(m.Value)}}, nil
+}
+
+func (m mockedSSMGetParametersAPI) GetParametersByPath(_ context.Context, _ *ssm.GetParametersByPathInput, _ ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error) {
+	output := &ssm.GetParametersByPathOutput{}
+	for _, name := range m.Names {
+		parameter := ssmtypes.Parameter{Name: aws.String(name), Value: aws.String(m.Value)}
+		if modified, ok := m.Modified[name]; ok {
+			parameter.LastModifiedDate = aws.Time(modified)
+		}
+		output.Parameters = append(output.Parameters, parameter)
+	}
+	return output, nil
+}
+
 func TestRemoteSuccess(t *testing.T) {
 	var client RemoteClient
 	client.AWSEC2Client = mockedEc2DescribeImagesAPI{
@@ -70,21 +99,21 @@ func TestDummyRemoteExportWithoutUpdate(t *testing.T) {
 	client, err := NewRemoteClient()
 	require.NoError(t, err)
 
-	updates, err := client.RemoteExport("../testdata/remote-dummy.yaml")
+	export, err := client.RemoteExport("../testdata/remote-dummy.yaml")
 	require.NoError(t, err)
-	require.Empty(t, updates)
+	require.Empty(t, export.Updates)
 }
 
 func TestDummyRemoteExportWithUpdate(t *testing.T) {
 	client, err := NewRemoteClient()
 	require.NoError(t, err)
 
-	updates, err := client.RemoteExport("../testdata/remote-dummy-with-update.yaml")
+	export, err := client.RemoteExport("../testdata/remote-dummy-with-update.yaml")
 	require.NoError(t, err)
-	require.NotEmpty(t, updates)
-	require.Equal(t, "example", updates[0].Name)
-	require.Equal(t, "0.0.1", updates[0].Version)
-	require.Equal(t, "1.0.0", updates[0].NewVersion)
+	require.NotEmpty(t, export.Updates)
+	require.Equal(t, "example", export.Updates[0].Name)
+	require.Equal(t, "0.0.1", export.Updates[0].Version)
+	require.Equal(t, "1.0.0", export.Updates[0].NewVersion)
 }
 
 func TestRemoteConstraint(t *testing.T) {
@@ -257,3 +286,663 @@ dependencies:
 	require.NoError(t, err)
 	require.Equal(t, "VERSION: 1.0.0\nOTHER: 0.0.1", string(got))
 }
+
+func TestUpgradeOnUpgrade(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "test.txt"), []byte("VERSION: 0.0.1\nOTHER: 0.0.1"), 0o644)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "other.txt"), []byte("OTHER: 0.0.2"), 0o644)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(dir, "dependencies.yaml"), []byte(`
+dependencies:
+  - name: upgrade
+    version: 0.0.1
+    upstream:
+      flavour: dummy
+    refPaths:
+    - path: test.txt
+      match: VERSION
+  - name: other
+    version: 0.0.1
+    upstream:
+      flavour: dummy
+    refPaths:
+    - path: test.txt
+      match: OTHER
+    - path: other.txt
+      match: OTHER
+`), 0o644)
+	require.NoError(t, err)
+
+	var upgrades []*deppkg.Upgrade
+	var versions []map[string]string
+	client, err := NewRemoteClient(deppkg.WithOnUpgrade(func(upgrade *deppkg.Upgrade) error {
+		// The dependencies file is written before each call
+		deps, err := deppkg.FromFile(filepath.Join(dir, "dependencies.yaml"))
+		require.NoError(t, err)
+		upgrades = append(upgrades, upgrade)
+		versions = append(versions, deps.Versions())
+		return nil
+	}))
+	require.NoError(t, err)
+
+	_, err = client.Upgrade(filepath.Join(dir, "dependencies.yaml"), dir)
+	require.NoError(t, err)
+
+	// other.txt does not contain the current version, and is not changed
+	require.Equal(t, []*deppkg.Upgrade{
+		{Name: "upgrade", Current: "0.0.1", Latest: "1.0.0", Files: []string{filepath.Join(dir, "test.txt"), filepath.Join(dir, "dependencies.yaml")}},
+		{Name: "other", Current: "0.0.1", Latest: "1.0.0", Files: []string{filepath.Join(dir, "test.txt"), filepath.Join(dir, "dependencies.yaml")}},
+	}, upgrades)
+	require.Equal(t, []map[string]string{
+		{"upgrade": "1.0.0", "other": "0.0.1"},
+		{"upgrade": "1.0.0", "other": "1.0.0"},
+	}, versions)
+}
+
+func TestUpgradeBeforeUpgrade(t *testing.T) {
+	dir := t.TempDir()
+	dependencies := `
+dependencies:
+  - name: upgrade
+    version: 0.0.1
+    upstream:
+      flavour: dummy
+    refPaths:
+    - path: test.txt
+      match: VERSION
+  - name: other
+    version: 0.0.1
+    upstream:
+      flavour: dummy
+    refPaths:
+    - path: other.txt
+      match: OTHER
+`
+	files := map[string]string{
+		"test.txt":          "VERSION: 0.0.1",
+		"other.txt":         "OTHER: 0.0.1",
+		"dependencies.yaml": dependencies,
+	}
+	for file, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0o644))
+	}
+
+	var checked []string
+	client, err := NewRemoteClient(
+		deppkg.WithBeforeUpgrade(func(upgrade *deppkg.Upgrade) error {
+			checked = append(checked, upgrade.Name)
+			if upgrade.Name == "other" {
+				return errors.New("honk")
+			}
+			return nil
+		}),
+		deppkg.WithOnUpgrade(func(*deppkg.Upgrade) error {
+			require.Fail(t, "no upgrade should be applied")
+			return nil
+		}),
+	)
+	require.NoError(t, err)
+
+	_, err = client.Upgrade(filepath.Join(dir, "dependencies.yaml"), dir)
+	require.EqualError(t, err, "honk")
+	require.Equal(t, []string{"upgrade", "other"}, checked)
+
+	// Refusing any upgrade leaves every file untouched
+	for file, content := range files {
+		got, err := os.ReadFile(filepath.Join(dir, file))
+		require.NoError(t, err)
+		require.Equal(t, content, string(got))
+	}
+}
+
+func TestUpgradeDigest(t *testing.T) {
+	dir := t.TempDir()
+	testFile := filepath.Join(dir, "test.txt")
+
+	err := os.WriteFile(testFile, []byte("image: honk:1.0.0@sha256:aaaa\nother: honk:1.0.0"), 0o644)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(dir, "dependencies.yaml"), []byte(`
+dependencies:
+  - name: honk
+    version: 1.0.0@sha256:aaaa
+    scheme: semver
+    upstream:
+      flavour: dummy
+      latest: 1.0.0@sha256:bbbb
+    refPaths:
+    - path: test.txt
+      match: image
+`), 0o644)
+	require.NoError(t, err)
+
+	client, err := NewRemoteClient()
+	require.NoError(t, err)
+	ret, err := client.Upgrade(filepath.Join(dir, "dependencies.yaml"), dir)
+	require.NoError(t, err)
+
+	require.Len(t, ret, 1)
+	require.Equal(t, "Upgraded dependency honk from version 1.0.0@sha256:aaaa to version 1.0.0@sha256:bbbb", ret[0])
+
+	got, err := os.ReadFile(testFile)
+	require.NoError(t, err)
+	require.Equal(t, "image: honk:1.0.0@sha256:bbbb\nother: honk:1.0.0", string(got))
+}
+
+func TestUpgradeToDigest(t *testing.T) {
+	dir := t.TempDir()
+	testFile := filepath.Join(dir, "test.txt")
+
+	err := os.WriteFile(testFile, []byte("image: honk:1.0.0"), 0o644)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(dir, "dependencies.yaml"), []byte(`
+dependencies:
+  - name: honk
+    version: 1.0.0
+    scheme: semver
+    upstream:
+      flavour: dummy
+      latest: 1.0.0@sha256:bbbb
+    refPaths:
+    - path: test.txt
+      match: image
+`), 0o644)
+	require.NoError(t, err)
+
+	// A tag which is not pinned yet moves to its digest
+	client, err := NewRemoteClient()
+	require.NoError(t, err)
+	ret, err := client.Upgrade(filepath.Join(dir, "dependencies.yaml"), dir)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"Upgraded dependency honk from version 1.0.0 to version 1.0.0@sha256:bbbb"}, ret)
+
+	got, err := os.ReadFile(testFile)
+	require.NoError(t, err)
+	require.Equal(t, "image: honk:1.0.0@sha256:bbbb", string(got))
+}
+
+func TestUpgradeKeyedVersions(t *testing.T) {
+	dir := t.TempDir()
+	testFile := filepath.Join(dir, "amis.tf")
+
+	err := os.WriteFile(testFile, []byte("ami_us_east_1 = \"ami-a\"\nami_eu_west_1 = \"ami-c\""), 0o644)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(dir, "dependencies.yaml"), []byte(`
+dependencies:
+  - name: ami
+    version: us-east-1=ami-a,eu-west-1=ami-c
+    scheme: random
+    upstream:
+      flavour: dummy
+      latest: us-east-1=ami-b,eu-west-1=ami-d
+    refPaths:
+    - path: amis.tf
+      match: ami_us_east_1
+      key: us-east-1
+    - path: amis.tf
+      match: ami_eu_west_1
+      key: eu-west-1
+`), 0o644)
+	require.NoError(t, err)
+
+	client, err := NewRemoteClient()
+	require.NoError(t, err)
+	ret, err := client.Upgrade(filepath.Join(dir, "dependencies.yaml"), dir)
+	require.NoError(t, err)
+	require.Len(t, ret, 1)
+
+	got, err := os.ReadFile(testFile)
+	require.NoError(t, err)
+	require.Equal(t, "ami_us_east_1 = \"ami-b\"\nami_eu_west_1 = \"ami-d\"", string(got))
+}
+
+func TestAWSClientOnlyForAMI(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(dir, "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+
+	client, err := NewRemoteClient()
+	require.NoError(t, err)
+
+	remoteClient, ok := client.(*RemoteClient)
+	require.True(t, ok)
+	require.Nil(t, remoteClient.AWSEC2Client)
+	require.Empty(t, remoteClient.ec2Clients)
+
+	_, err = client.CheckUpstreamVersions([]*deppkg.Dependency{
+		{
+			Name:    "ami",
+			Version: "ami-09bbefc07310f7914",
+			Scheme:  deppkg.Random,
+			Upstream: map[string]string{
+				"flavour": "ami",
+				"owner":   "amazon",
+				"name":    "amazon-eks-node-1.13-*",
+				"profile": "profile-that-does-not-exist",
+			},
+		},
+	})
+	require.ErrorContains(t, err, "dependency ami: loading AWS config")
+	require.Empty(t, remoteClient.ec2Clients)
+}
+
+func TestSSMRemote(t *testing.T) {
+	client := RemoteClient{
+		AWSSSMClient: mockedSSMGetParametersAPI{Value: "ami-new"},
+	}
+
+	updateInfos, err := client.CheckUpstreamVersions([]*deppkg.Dependency{
+		{
+			Name:    "eks-ami",
+			Version: "ami-old",
+			Scheme:  deppkg.Random,
+			Upstream: map[string]string{
+				"flavour":   "ssm",
+				"parameter": "/aws/service/eks/optimized-ami/1.30/amazon-linux-2/recommended/image_id",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, updateInfos, 1)
+	require.True(t, updateInfos[0].UpdateAvailable)
+	require.Equal(t, "ami-new", updateInfos[0].Latest.Version)
+}
+
+func TestPrereleasePolicyRemote(t *testing.T) {
+	client := RemoteClient{
+		AWSSSMClient: mockedSSMGetParametersAPI{
+			Names: []string{"/tool/1.0.0/url", "/tool/1.1.0/url", "/tool/1.2.0-rc.1/url"},
+		},
+	}
+
+	for _, tc := range []struct {
+		version     string
+		prereleases deppkg.PrereleasePolicy
+		expected    string
+	}{
+		{version: "1.0.0", expected: "1.1.0"},
+		{version: "1.1.0-rc.2", expected: "1.2.0-rc.1"},
+		{version: "1.1.0-rc.2", prereleases: deppkg.PrereleasesExclude, expected: "1.1.0"},
+		{version: "1.0.0", prereleases: deppkg.PrereleasesInclude, expected: "1.2.0-rc.1"},
+	} {
+		updateInfos, err := client.CheckUpstreamVersions([]*deppkg.Dependency{
+			{
+				Name:        "tool",
+				Version:     tc.version,
+				Scheme:      deppkg.Semver,
+				Prereleases: tc.prereleases,
+				Upstream: map[string]string{
+					"flavour": "ssm",
+					"path":    "/tool",
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, updateInfos, 1)
+		require.Equal(t, tc.expected, updateInfos[0].Latest.Version)
+	}
+}
+
+func TestMinAgeRemote(t *testing.T) {
+	client := RemoteClient{
+		AWSSSMClient: mockedSSMGetParametersAPI{
+			Names: []string{"/tool/1.0.0/url", "/tool/1.1.0/url"},
+			Modified: map[string]time.Time{
+				"/tool/1.0.0/url": time.Now().Add(-30 * 24 * time.Hour),
+				"/tool/1.1.0/url": time.Now().Add(-time.Hour),
+			},
+		},
+	}
+
+	dep := &deppkg.Dependency{
+		Name:    "tool",
+		Version: "1.0.0",
+		Scheme:  deppkg.Semver,
+		MinAge:  "7d",
+		Upstream: map[string]string{
+			"flavour": "ssm",
+			"path":    "/tool",
+		},
+	}
+
+	updateInfos, err := client.CheckUpstreamVersions([]*deppkg.Dependency{dep})
+	require.NoError(t, err)
+	require.Len(t, updateInfos, 1)
+	require.False(t, updateInfos[0].UpdateAvailable)
+
+	dep.MinAge = "1h30"
+	_, err = client.CheckUpstreamVersions([]*deppkg.Dependency{dep})
+	require.Error(t, err)
+}
+
+func TestEKSRemote(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(rw, `[
+			{"cycle": "1.31", "eol": "2099-11-26", "extendedSupport": "2099-11-26", "latest": "1.31-eks-12"},
+			{"cycle": "1.29", "eol": "2025-03-23", "extendedSupport": "2099-03-23", "latest": "1.29-eks-25"}
+		]`)
+	}))
+	defer server.Close()
+
+	client, err := NewRemoteClient()
+	require.NoError(t, err)
+
+	updateInfos, err := client.CheckUpstreamVersions([]*deppkg.Dependency{{
+		Name:    "eks",
+		Version: "1.29",
+		Scheme:  deppkg.Semver,
+		Upstream: map[string]string{
+			"flavour": "eks",
+			"server":  server.URL,
+		},
+	}})
+	require.NoError(t, err)
+	require.Len(t, updateInfos, 1)
+
+	// The format of the current version is kept
+	require.True(t, updateInfos[0].UpdateAvailable)
+	require.Equal(t, "1.31", updateInfos[0].Latest.Version)
+}
+
+func TestIgnoreVersionsRemote(t *testing.T) {
+	client := RemoteClient{
+		AWSSSMClient: mockedSSMGetParametersAPI{
+			Names: []string{"/tool/1.0.0/url", "/tool/1.1.0/url", "/tool/1.1.1/url", "/tool/1.2.0/url"},
+		},
+	}
+
+	updateInfos, err := client.CheckUpstreamVersions([]*deppkg.Dependency{
+		{
+			Name:           "tool",
+			Version:        "1.0.0",
+			Scheme:         deppkg.Semver,
+			IgnoreVersions: []string{"1.2.0", ">= 1.1.1 < 1.2.0"},
+			Upstream: map[string]string{
+				"flavour": "ssm",
+				"path":    "/tool",
+			},
+		},
+		{
+			Name:           "dummy",
+			Version:        "0.0.1",
+			Scheme:         deppkg.Semver,
+			IgnoreVersions: []string{"1.0.0"},
+			Upstream: map[string]string{
+				"flavour": "dummy",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, updateInfos, 2)
+
+	require.True(t, updateInfos[0].UpdateAvailable)
+	require.Equal(t, "1.1.0", updateInfos[0].Latest.Version)
+
+	// Dummy only returns 1.0.0, which is ignored
+	require.False(t, updateInfos[1].UpdateAvailable)
+	require.Equal(t, []deppkg.SkippedVersion{{Version: "1.0.0", Reason: "ignored"}}, updateInfos[1].Skipped)
+}
+
+func TestHoldRemote(t *testing.T) {
+	client, err := NewRemoteClient()
+	require.NoError(t, err)
+
+	updates, err := client.RemoteCheck("../testdata/remote-dummy-held.yaml")
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"Dependency held is held until 2999-01-01: waiting for the migration (current: 0.0.1, latest: 1.0.0)",
+		"Dependency held-up-to-date is held until 2999-01-01 (current: 1.0.0, latest: 1.0.0)",
+		"Update available for dependency hold-expired: 1.0.0 (current: 0.0.1)",
+	}, updates)
+
+	// Export updates only list updates
+	export, err := client.RemoteExport("../testdata/remote-dummy-held.yaml")
+	require.NoError(t, err)
+	require.Len(t, export.Updates, 1)
+	require.Equal(t, "hold-expired", export.Updates[0].Name)
+	require.Equal(t, "1.0.0", export.Updates[0].NewVersion)
+	require.Nil(t, export.Updates[0].Hold)
+
+	// Held dependencies are withheld, along with the versions they skip
+	require.Len(t, export.Withheld, 2)
+
+	require.Equal(t, "held", export.Withheld[0].Name)
+	require.Equal(t, "waiting for the migration", export.Withheld[0].Hold.Reason)
+	require.Equal(t, []deppkg.SkippedVersion{
+		{Version: "1.0.0", Reason: "held until 2999-01-01: waiting for the migration"},
+	}, export.Withheld[0].Skipped)
+
+	require.Equal(t, "held-up-to-date", export.Withheld[1].Name)
+	require.NotNil(t, export.Withheld[1].Hold)
+	require.Empty(t, export.Withheld[1].Skipped)
+}
+
+func TestUpgradeSkewRules(t *testing.T) {
+	dir := t.TempDir()
+	testFile := filepath.Join(dir, "versions.txt")
+
+	err := os.WriteFile(testFile, []byte("eks: 1.29\nkubectl: 1.29.0\nnode: 1.29.0\nhelm: 1.0.0"), 0o644)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(dir, "dependencies.yaml"), []byte(`
+dependencies:
+  - name: eks
+    version: "1.29"
+    upstream:
+      flavour: dummy
+      latest: "1.31"
+    refPaths:
+    - path: versions.txt
+      match: eks
+  - name: kubectl
+    version: 1.29.0
+    upstream:
+      flavour: dummy
+      latest: 1.31.2
+    refPaths:
+    - path: versions.txt
+      match: kubectl
+  - name: node
+    version: 1.29.0
+    upstream:
+      flavour: dummy
+      latest: 1.32.0
+    refPaths:
+    - path: versions.txt
+      match: node
+  - name: helm
+    version: 1.0.0
+    upstream:
+      flavour: dummy
+      latest: 2.0.0
+    refPaths:
+    - path: versions.txt
+      match: helm
+rules:
+  - dependency: kubectl
+    relativeTo: eks
+    level: minor
+    maxSkew: 1
+  - name: nodes cannot be newer than the control plane
+    dependency: node
+    relativeTo: eks
+    level: minor
+    maxSkew: 3
+    direction: older
+`), 0o644)
+	require.NoError(t, err)
+
+	client, err := NewRemoteClient()
+	require.NoError(t, err)
+	ret, err := client.Upgrade(filepath.Join(dir, "dependencies.yaml"), dir)
+	require.NoError(t, err)
+
+	// eks and kubectl are upgraded together, node would be newer than eks
+	require.Equal(t, []string{
+		"Upgraded dependency eks from version 1.29 to version 1.31",
+		"Upgraded dependency kubectl from version 1.29.0 to version 1.31.2",
+		"Refused to upgrade dependency node from version 1.29.0 to version 1.32.0: " +
+			"rule nodes cannot be newer than the control plane is broken: node is at 1.32.0 and eks at 1.31",
+		"Upgraded dependency helm from version 1.0.0 to version 2.0.0",
+	}, ret)
+
+	got, err := os.ReadFile(testFile)
+	require.NoError(t, err)
+	require.Equal(t, "eks: 1.31\nkubectl: 1.31.2\nnode: 1.29.0\nhelm: 2.0.0", string(got))
+
+	// Rules are kept in the dependencies file
+	deps, err := deppkg.FromFile(filepath.Join(dir, "dependencies.yaml"))
+	require.NoError(t, err)
+	require.Len(t, deps.Rules, 2)
+	require.NoError(t, client.LocalCheck(filepath.Join(dir, "dependencies.yaml"), dir))
+}
+
+func TestStalenessRemote(t *testing.T) {
+	client := RemoteClient{
+		AWSSSMClient: mockedSSMGetParametersAPI{
+			Names: []string{"/tool/1.0.0/url", "/tool/1.0.1/url", "/tool/1.2.0/url", "/tool/1.3.0-rc.1/url"},
+			Modified: map[string]time.Time{
+				"/tool/1.0.1/url": time.Now().Add(-40 * 24 * time.Hour),
+				"/tool/1.2.0/url": time.Now().Add(-10 * 24 * time.Hour),
+			},
+		},
+	}
+
+	dir := t.TempDir()
+	dependencies := filepath.Join(dir, "dependencies.yaml")
+	writeDependencies := func(thresholds string) {
+		err := os.WriteFile(dependencies, []byte(`
+dependencies:
+  - name: tool
+    version: 1.0.0
+    upstream:
+      flavour: ssm
+      path: /tool
+    refPaths:
+    - path: versions.txt
+      match: tool
+`+thresholds), 0o644)
+		require.NoError(t, err)
+	}
+
+	writeDependencies("")
+	updateInfos, err := client.CheckUpstreamVersions([]*deppkg.Dependency{{
+		Name:     "tool",
+		Version:  "1.0.0",
+		Scheme:   deppkg.Semver,
+		Upstream: map[string]string{"flavour": "ssm", "path": "/tool"},
+	}})
+	require.NoError(t, err)
+	require.Len(t, updateInfos, 1)
+	require.NotNil(t, updateInfos[0].Staleness)
+	require.Equal(t, 2, updateInfos[0].Staleness.NewerReleases)
+	require.Equal(t, 0, updateInfos[0].Staleness.MajorsBehind)
+	require.Equal(t, 2, updateInfos[0].Staleness.MinorsBehind)
+	require.Equal(t, 1, updateInfos[0].Staleness.PatchesBehind)
+	require.Equal(t, 40, updateInfos[0].Staleness.DaysBehind)
+
+	updates, err := client.RemoteCheck(dependencies)
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+
+	exported, err := client.RemoteExport(dependencies)
+	require.NoError(t, err)
+	require.Len(t, exported.Updates, 1)
+	require.Equal(t, 2, exported.Updates[0].Staleness.NewerReleases)
+
+	for thresholds, lagging := range map[string]bool{
+		"    maxBehind: 2 minors\n":                             false,
+		"    maxBehind: 1 minor\n":                              true,
+		"    maxBehind: 1 release\n":                            true,
+		"    maxBehind: 0 majors\n    maxAge: 60d":              false,
+		"    maxAge: 30d\n":                                     true,
+		"    maxAge: 30d\n    hold:\n      until: 2999-01-01\n": false,
+	} {
+		writeDependencies(thresholds)
+		updates, err := client.RemoteCheck(dependencies)
+		if lagging {
+			require.Error(t, err, thresholds)
+		} else {
+			require.NoError(t, err, thresholds)
+		}
+		require.Len(t, updates, 1, thresholds)
+	}
+
+	// Versions outside of the constraints never make the dependency lag
+	err = os.WriteFile(dependencies, []byte(`
+dependencies:
+  - name: tool
+    version: 1.0.0
+    upstream:
+      flavour: ssm
+      path: /tool
+      constraints: < 1.2.0
+    refPaths:
+    - path: versions.txt
+      match: tool
+    maxBehind: 0 minors
+`), 0o644)
+	require.NoError(t, err)
+
+	updates, err = client.RemoteCheck(dependencies)
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+
+	exported, err = client.RemoteExport(dependencies)
+	require.NoError(t, err)
+	require.Equal(t, 1, exported.Updates[0].Staleness.NewerReleases)
+	require.Equal(t, 0, exported.Updates[0].Staleness.MinorsBehind)
+}
+
+func TestChangelogRemote(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		http.ServeFile(rw, req, "../../testdata/helm-repo/index.yaml")
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "values.yaml"), []byte("chart: 0.1.1\n"), 0o644)
+	require.NoError(t, err)
+
+	dependencies := filepath.Join(dir, "dependencies.yaml")
+	err = os.WriteFile(dependencies, []byte(`
+dependencies:
+  - name: chart
+    version: 0.1.1
+    upstream:
+      flavour: helm
+      repo: `+server.URL+`
+      chart: dependency
+    refPaths:
+    - path: values.yaml
+      match: chart
+`), 0o644)
+	require.NoError(t, err)
+
+	// Release notes are only collected when asked for
+	client, err := NewRemoteClient()
+	require.NoError(t, err)
+	exported, err := client.RemoteExport(dependencies)
+	require.NoError(t, err)
+	require.Len(t, exported.Updates, 1)
+	require.Empty(t, exported.Updates[0].Changelog)
+
+	client, err = NewRemoteClient(deppkg.WithChangelog())
+	require.NoError(t, err)
+	exported, err = client.RemoteExport(dependencies)
+	require.NoError(t, err)
+	require.Len(t, exported.Updates, 1)
+	require.Contains(t, exported.Updates[0].Changelog, "## chart 0.1.1 → 0.2.0")
+	require.Contains(t, exported.Updates[0].Changelog, "> - **changed**: Breaking: values.image is now values.image.repository")
+	require.Contains(t, exported.Updates[0].Changelog, "### 0.1.2\n\n- **fixed**: Fix service port")
+
+	upgrades, err := client.Upgrade(dependencies, dir)
+	require.NoError(t, err)
+	require.Len(t, upgrades, 1)
+	require.Equal(t, "Upgraded dependency chart from version 0.1.1 to version 0.2.0\n\n"+exported.Updates[0].Changelog, upgrades[0])
+}
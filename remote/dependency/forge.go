@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/google/go-github/v60/github"
+
+	"sigs.k8s.io/release-utils/env"
+	deppkg "sigs.k8s.io/zeitgeist/dependency"
+	"sigs.k8s.io/zeitgeist/pkg/git"
+	"sigs.k8s.io/zeitgeist/pkg/gitlab"
+)
+
+// githubTokenEnvKey is the GitHub token environment variable key, as used by
+// the github upstream.
+const githubTokenEnvKey = "GITHUB_TOKEN"
+
+// forge opens pull or merge requests on a code hosting platform.
+type forge interface {
+	// openPullRequest returns the URL of an open pull request from info.Head
+	// to info.Base, or an empty string if there is none.
+	openPullRequest(info *git.Info) (string, error)
+	// createPullRequest opens a pull request from info.Head to info.Base and
+	// returns its URL.
+	createPullRequest(info *git.Info, title, body string) (string, error)
+}
+
+// newForge creates a client of the platform, along with the authentication
+// to push branches over HTTPS.
+func newForge(platform deppkg.Platform, server string) (forge, transport.AuthMethod, error) {
+	switch platform {
+	case "", deppkg.PlatformGitHub:
+		token := env.Default(githubTokenEnvKey, "")
+		if token == "" {
+			return nil, nil, fmt.Errorf("$%s is required to open pull requests", githubTokenEnvKey)
+		}
+
+		client := github.NewClient(nil).WithAuthToken(token)
+		if server != "" {
+			var err error
+			if client, err = client.WithEnterpriseURLs(server, server); err != nil {
+				return nil, nil, fmt.Errorf("invalid GitHub server %s: %w", server, err)
+			}
+		}
+
+		return &githubForge{client: client}, &githttp.BasicAuth{Username: "x-access-token", Password: token}, nil
+
+	case deppkg.PlatformGitLab:
+		client, tokenEnv := gitlab.New(), gitlab.TokenEnvKey
+		if server != "" {
+			client, tokenEnv = gitlab.NewPrivate(strings.TrimSuffix(server, "/")+"/"), gitlab.PrivateTokenEnvKey
+		}
+		if client == nil {
+			return nil, nil, fmt.Errorf("$%s is required to open merge requests", tokenEnv)
+		}
+
+		return &gitlabForge{client: client}, &githttp.BasicAuth{Username: "oauth2", Password: env.Default(tokenEnv, "")}, nil
+
+	default:
+		return nil, nil, fmt.Errorf("unknown platform %q, should be github or gitlab", platform)
+	}
+}
+
+type githubForge struct {
+	client *github.Client
+}
+
+func (f *githubForge) openPullRequest(info *git.Info) (string, error) {
+	pullRequests, _, err := f.client.PullRequests.List(
+		context.Background(), info.Org, info.Repo,
+		&github.PullRequestListOptions{State: "open", Head: info.GetHeadRef(), Base: info.Base},
+	)
+	if err != nil {
+		return "", fmt.Errorf("listing pull requests of %s/%s: %w", info.Org, info.Repo, err)
+	}
+
+	if len(pullRequests) == 0 {
+		return "", nil
+	}
+	return pullRequests[0].GetHTMLURL(), nil
+}
+
+func (f *githubForge) createPullRequest(info *git.Info, title, body string) (string, error) {
+	pullRequest, _, err := f.client.PullRequests.Create(
+		context.Background(), info.Org, info.Repo,
+		&github.NewPullRequest{Title: &title, Head: github.String(info.GetHeadRef()), Base: &info.Base, Body: &body},
+	)
+	if err != nil {
+		return "", fmt.Errorf("creating pull request on %s/%s: %w", info.Org, info.Repo, err)
+	}
+
+	return pullRequest.GetHTMLURL(), nil
+}
+
+type gitlabForge struct {
+	client *gitlab.GitLab
+}
+
+func (f *gitlabForge) openPullRequest(info *git.Info) (string, error) {
+	mergeRequests, err := f.client.OpenMergeRequests(info.Org, info.Repo, info.Head, info.Base)
+	if err != nil {
+		return "", err
+	}
+
+	if len(mergeRequests) == 0 {
+		return "", nil
+	}
+	return mergeRequests[0].WebURL, nil
+}
+
+func (f *gitlabForge) createPullRequest(info *git.Info, title, body string) (string, error) {
+	mergeRequest, err := f.client.CreateMergeRequest(info.Org, info.Repo, info.Head, info.Base, title, body)
+	if err != nil {
+		return "", err
+	}
+
+	return mergeRequest.WebURL, nil
+}
+
+// parseRepository returns the owner and name of a repository from its remote
+// URL, e.g. https://github.com/kubernetes-sigs/zeitgeist.git or
+// git@gitlab.com:group/subgroup/project.git.
+func parseRepository(remoteURL string) (owner, repo string, err error) {
+	path := remoteURL
+	if strings.Contains(remoteURL, "://") {
+		parsed, err := url.Parse(remoteURL)
+		if err != nil {
+			return "", "", err
+		}
+		path = parsed.Path
+	} else if _, after, ok := strings.Cut(remoteURL, ":"); ok {
+		path = after
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	owner, repo, ok := cutLast(path, "/")
+	if !ok || owner == "" || repo == "" {
+		return "", "", errors.New("cannot find the repository owner and name in remote URL " + remoteURL)
+	}
+
+	return owner, repo, nil
+}
+
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
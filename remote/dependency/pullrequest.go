@@ -0,0 +1,183 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	deppkg "sigs.k8s.io/zeitgeist/dependency"
+	"sigs.k8s.io/zeitgeist/pkg/git"
+)
+
+const defaultRemote = "origin"
+
+// OpenPullRequests opens a pull or merge request for each available update,
+// or group of updates, unless one is already open. For each of them, a branch
+// is created from the base branch, the dependency is upgraded, and the
+// changes are committed and pushed. Branches which already exist on the
+// remote are left alone. The base branch is checked out again afterwards.
+//
+// Will return an error if the repository has uncommitted changes, or if
+// checking the versions upstream, updating files or opening a pull request
+// fails.
+func (c *RemoteClient) OpenPullRequests(dependencyFilePath, basePath string, opts *deppkg.PullRequestOptions) ([]string, error) {
+	worktree, err := git.OpenWorktree(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := worktree.Changes()
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) > 0 {
+		return nil, fmt.Errorf("the repository has uncommitted changes: %s", strings.Join(changes, ", "))
+	}
+
+	remote := opts.Remote
+	if remote == "" {
+		remote = defaultRemote
+	}
+	remoteURL, err := worktree.RemoteURL(remote)
+	if err != nil {
+		return nil, err
+	}
+
+	info := opts.Git
+	if info.Base == "" {
+		if info.Base, err = worktree.Branch(); err != nil {
+			return nil, fmt.Errorf("finding the base branch: %w", err)
+		}
+	}
+	if info.Org == "" || info.Repo == "" {
+		if info.Org, info.Repo, err = parseRepository(remoteURL); err != nil {
+			return nil, err
+		}
+	}
+	if info.UserID == "" {
+		info.UserID = info.Org
+	}
+
+	forge, auth, err := newForge(opts.Platform, opts.Server)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(remoteURL, "http://") && !strings.HasPrefix(remoteURL, "https://") {
+		// SSH or local remotes have their own authentication
+		auth = nil
+	}
+
+	externalDeps, err := deppkg.FromFile(dependencyFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	export, err := c.RemoteExport(dependencyFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := worktree.Checkout(info.Base); err != nil {
+			log.Errorf("Checking out %s again: %v", info.Base, err)
+		}
+	}()
+
+	results := make([]string, 0)
+	for _, proposal := range deppkg.Proposals(externalDeps.Dependencies, export.Updates) {
+		versions := externalDeps.Versions()
+		for _, update := range proposal.Updates {
+			versions[update.Name] = update.NewVersion
+		}
+		if err := deppkg.CheckRules(externalDeps.Rules, versions); err != nil {
+			results = append(results, fmt.Sprintf("Refused to open a pull request for %s: %v", proposal.Name, err))
+			continue
+		}
+
+		branch, title, body, message, err := opts.Render(&proposal)
+		if err != nil {
+			return nil, err
+		}
+		info.Head = branch
+
+		existing, err := forge.openPullRequest(&info)
+		if err != nil {
+			return nil, err
+		}
+		if existing != "" {
+			results = append(results, fmt.Sprintf("Pull request for %s already open: %s", proposal.Name, existing))
+			continue
+		}
+
+		// Branches are never overwritten, since they may hold commits of
+		// someone else, e.g. of a closed pull request
+		exists, err := worktree.RemoteBranchExists(remote, branch, auth)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			results = append(results, fmt.Sprintf(
+				"Refused to open a pull request for %s: branch %s already exists on %s, delete it to have it recreated",
+				proposal.Name, branch, remote,
+			))
+			continue
+		}
+
+		if err := c.commitProposal(worktree, dependencyFilePath, basePath, &info, &proposal, message); err != nil {
+			return nil, err
+		}
+
+		if err := worktree.Push(remote, branch, auth); err != nil {
+			return nil, err
+		}
+
+		pullRequest, err := forge.createPullRequest(&info, title, body)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, fmt.Sprintf("Opened pull request for %s: %s", proposal.Name, pullRequest))
+	}
+
+	return results, nil
+}
+
+// commitProposal creates the head branch of info from its base, upgrades the
+// dependencies of the proposal and commits the changed files.
+func (c *RemoteClient) commitProposal(
+	worktree *git.Worktree, dependencyFilePath, basePath string, info *git.Info, proposal *deppkg.Proposal, message string,
+) error {
+	if err := worktree.CreateBranch(info.Head, info.Base); err != nil {
+		return err
+	}
+
+	for _, update := range proposal.Updates {
+		if err := c.SetVersion(dependencyFilePath, basePath, update.Name, update.NewVersion); err != nil {
+			return fmt.Errorf("upgrading %s: %w", update.Name, err)
+		}
+	}
+
+	changes, err := worktree.Changes()
+	if err != nil {
+		return err
+	}
+
+	_, err = worktree.Commit(changes, message, info)
+	return err
+}
@@ -0,0 +1,322 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/require"
+
+	deppkg "sigs.k8s.io/zeitgeist/dependency"
+	"sigs.k8s.io/zeitgeist/pkg/git"
+)
+
+const pullRequestDependencies = `
+dependencies:
+  - name: tool
+    version: 0.0.1
+    upstream:
+      flavour: dummy
+    refPaths:
+    - path: versions.txt
+      match: tool
+  - name: other
+    version: 0.0.1
+    upstream:
+      flavour: dummy
+    refPaths:
+    - path: versions.txt
+      match: other
+  - name: first
+    version: 0.0.1
+    group: pair
+    upstream:
+      flavour: dummy
+    refPaths:
+    - path: versions.txt
+      match: first
+  - name: second
+    version: 0.0.1
+    group: pair
+    upstream:
+      flavour: dummy
+    refPaths:
+    - path: versions.txt
+      match: second
+  - name: current
+    version: 1.0.0
+    upstream:
+      flavour: dummy
+    refPaths:
+    - path: versions.txt
+      match: current
+`
+
+const pullRequestVersions = "tool: 0.0.1\nother: 0.0.1\nfirst: 0.0.1\nsecond: 0.0.1\ncurrent: 1.0.0\n"
+
+// newPullRequestRepo creates a repository with dependencies on main, pushed
+// to a bare origin repository, and returns the paths of both.
+func newPullRequestRepo(t *testing.T) (work, origin string) {
+	t.Helper()
+
+	origin = t.TempDir()
+	_, err := gogit.PlainInit(origin, true)
+	require.NoError(t, err)
+
+	work = t.TempDir()
+	repo, err := gogit.PlainInitWithOptions(work, &gogit.PlainInitOptions{
+		InitOptions: gogit.InitOptions{DefaultBranch: plumbing.NewBranchReferenceName("main")},
+	})
+	require.NoError(t, err)
+	_, err = repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{origin}})
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(work, "dependencies.yaml"), []byte(pullRequestDependencies), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(work, "versions.txt"), []byte(pullRequestVersions), 0o644))
+
+	worktree, err := git.OpenWorktree(work)
+	require.NoError(t, err)
+	_, err = worktree.Commit([]string{"dependencies.yaml", "versions.txt"}, "Initial commit", &git.Info{UserName: "Honk", Email: "honk@example.com"})
+	require.NoError(t, err)
+	require.NoError(t, worktree.Push("origin", "main", nil))
+
+	return work, origin
+}
+
+// githubPullRequests is a stand-in for the pull requests API of GitHub, with
+// an open pull request for the upgrade of `other`. Created pull requests are
+// open until removed from `open`.
+type githubPullRequests struct {
+	mu      sync.Mutex
+	created []map[string]string
+	open    map[string]string
+}
+
+func newGithubPullRequests() *githubPullRequests {
+	return &githubPullRequests{open: map[string]string{"honk:zeitgeist/other": "https://github.com/honk/repo/pull/1"}}
+}
+
+func (g *githubPullRequests) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if req.URL.Path != "/api/v3/repos/honk/repo/pulls" || req.Header.Get("Authorization") != "Bearer honk-token" {
+		rw.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	switch req.Method {
+	case http.MethodGet:
+		if url, ok := g.open[req.URL.Query().Get("head")]; ok && req.URL.Query().Get("state") == "open" {
+			fmt.Fprintf(rw, `[{"number": 1, "html_url": %q}]`, url)
+			return
+		}
+		fmt.Fprint(rw, `[]`)
+	case http.MethodPost:
+		created := map[string]string{}
+		if err := json.NewDecoder(req.Body).Decode(&created); err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		g.created = append(g.created, created)
+		url := fmt.Sprintf("https://github.com/honk/repo/pull/%d", len(g.created)+1)
+		g.open[created["head"]] = url
+		rw.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(rw, `{"number": %d, "html_url": %q}`, len(g.created)+1, url)
+	}
+}
+
+func TestOpenPullRequests(t *testing.T) {
+	work, origin := newPullRequestRepo(t)
+
+	pullRequests := newGithubPullRequests()
+	server := httptest.NewServer(pullRequests)
+	defer server.Close()
+	t.Setenv("GITHUB_TOKEN", "honk-token")
+
+	client, err := NewRemoteClient()
+	require.NoError(t, err)
+
+	opts := &deppkg.PullRequestOptions{
+		Git:    git.Info{Org: "honk", Repo: "repo", UserName: "Zeitgeist", Email: "zeitgeist@example.com"},
+		Server: server.URL,
+	}
+	results, err := client.OpenPullRequests(filepath.Join(work, "dependencies.yaml"), work, opts)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"Opened pull request for tool: https://github.com/honk/repo/pull/2",
+		"Pull request for other already open: https://github.com/honk/repo/pull/1",
+		"Opened pull request for pair: https://github.com/honk/repo/pull/3",
+	}, results)
+
+	require.Len(t, pullRequests.created, 2)
+	require.Equal(t, "Upgrade tool from 0.0.1 to 1.0.0", pullRequests.created[0]["title"])
+	require.Equal(t, "honk:zeitgeist/tool", pullRequests.created[0]["head"])
+	require.Equal(t, "main", pullRequests.created[0]["base"])
+	require.Equal(t, "- Upgrade tool from 0.0.1 to 1.0.0\n", pullRequests.created[0]["body"])
+	require.Equal(t, "Upgrade pair", pullRequests.created[1]["title"])
+	require.Equal(t, "honk:zeitgeist/pair", pullRequests.created[1]["head"])
+
+	// Branches are pushed with only their upgrades
+	remote, err := gogit.PlainOpen(origin)
+	require.NoError(t, err)
+	branchFile := func(branch, path string) string {
+		ref, err := remote.Reference(plumbing.NewBranchReferenceName(branch), true)
+		require.NoError(t, err)
+		commit, err := remote.CommitObject(ref.Hash())
+		require.NoError(t, err)
+		require.Equal(t, "Zeitgeist", commit.Author.Name)
+		file, err := commit.File(path)
+		require.NoError(t, err)
+		contents, err := file.Contents()
+		require.NoError(t, err)
+		return contents
+	}
+	require.Equal(t, "tool: 1.0.0\nother: 0.0.1\nfirst: 0.0.1\nsecond: 0.0.1\ncurrent: 1.0.0\n", branchFile("zeitgeist/tool", "versions.txt"))
+	require.Equal(t, "tool: 0.0.1\nother: 0.0.1\nfirst: 1.0.0\nsecond: 1.0.0\ncurrent: 1.0.0\n", branchFile("zeitgeist/pair", "versions.txt"))
+	require.Contains(t, branchFile("zeitgeist/tool", "dependencies.yaml"), "version: 1.0.0")
+	_, err = remote.Reference(plumbing.NewBranchReferenceName("zeitgeist/other"), true)
+	require.Error(t, err)
+
+	// The base branch is checked out again, unchanged
+	worktree, err := git.OpenWorktree(work)
+	require.NoError(t, err)
+	branch, err := worktree.Branch()
+	require.NoError(t, err)
+	require.Equal(t, "main", branch)
+	versions, err := os.ReadFile(filepath.Join(work, "versions.txt"))
+	require.NoError(t, err)
+	require.Equal(t, pullRequestVersions, string(versions))
+
+	// Open pull requests are found again by their branch
+	results, err = client.OpenPullRequests(filepath.Join(work, "dependencies.yaml"), work, opts)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"Pull request for tool already open: https://github.com/honk/repo/pull/2",
+		"Pull request for other already open: https://github.com/honk/repo/pull/1",
+		"Pull request for pair already open: https://github.com/honk/repo/pull/3",
+	}, results)
+
+	// The branch of a closed pull request is not overwritten
+	delete(pullRequests.open, "honk:zeitgeist/tool")
+	results, err = client.OpenPullRequests(filepath.Join(work, "dependencies.yaml"), work, opts)
+	require.NoError(t, err)
+	require.Equal(t,
+		"Refused to open a pull request for tool: branch zeitgeist/tool already exists on origin, delete it to have it recreated",
+		results[0],
+	)
+	require.Len(t, pullRequests.created, 2)
+}
+
+func TestOpenPullRequestsUncommittedChanges(t *testing.T) {
+	work, _ := newPullRequestRepo(t)
+	require.NoError(t, os.WriteFile(filepath.Join(work, "versions.txt"), []byte("tool: 0.0.2\n"), 0o644))
+	t.Setenv("GITHUB_TOKEN", "honk-token")
+
+	client, err := NewRemoteClient()
+	require.NoError(t, err)
+
+	_, err = client.OpenPullRequests(filepath.Join(work, "dependencies.yaml"), work, &deppkg.PullRequestOptions{})
+	require.EqualError(t, err, "the repository has uncommitted changes: versions.txt")
+}
+
+func TestParseRepository(t *testing.T) {
+	for remoteURL, expected := range map[string][2]string{
+		"https://github.com/kubernetes-sigs/zeitgeist.git": {"kubernetes-sigs", "zeitgeist"},
+		"https://github.com/kubernetes-sigs/zeitgeist":     {"kubernetes-sigs", "zeitgeist"},
+		"git@gitlab.com:group/subgroup/project.git":        {"group/subgroup", "project"},
+		"ssh://git@gitlab.example.com:2222/group/project":  {"group", "project"},
+	} {
+		owner, repo, err := parseRepository(remoteURL)
+		require.NoError(t, err, remoteURL)
+		require.Equal(t, expected, [2]string{owner, repo}, remoteURL)
+	}
+
+	_, _, err := parseRepository("repo.git")
+	require.Error(t, err)
+}
+
+func TestGitLabForge(t *testing.T) {
+	var created map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v4/projects/honk/repo/merge_requests" || req.Header.Get("Private-Token") != "honk-token" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch req.Method {
+		case http.MethodGet:
+			if req.URL.Query().Get("source_branch") == "zeitgeist/open" && req.URL.Query().Get("state") == "opened" {
+				fmt.Fprint(rw, `[{"iid": 1, "web_url": "https://gitlab.example.com/honk/repo/-/merge_requests/1"}]`)
+				return
+			}
+			fmt.Fprint(rw, `[]`)
+		case http.MethodPost:
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&created))
+			rw.WriteHeader(http.StatusCreated)
+			fmt.Fprint(rw, `{"iid": 2, "web_url": "https://gitlab.example.com/honk/repo/-/merge_requests/2"}`)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("GITLAB_PRIVATE_TOKEN", "honk-token")
+
+	forge, auth, err := newForge(deppkg.PlatformGitLab, server.URL)
+	require.NoError(t, err)
+	require.NotNil(t, auth)
+
+	existing, err := forge.openPullRequest(&git.Info{Org: "honk", Repo: "repo", Head: "zeitgeist/open", Base: "main"})
+	require.NoError(t, err)
+	require.Equal(t, "https://gitlab.example.com/honk/repo/-/merge_requests/1", existing)
+
+	info := &git.Info{Org: "honk", Repo: "repo", Head: "zeitgeist/new", Base: "main"}
+	existing, err = forge.openPullRequest(info)
+	require.NoError(t, err)
+	require.Empty(t, existing)
+
+	mergeRequest, err := forge.createPullRequest(info, "Upgrade honk", "Honk!")
+	require.NoError(t, err)
+	require.Equal(t, "https://gitlab.example.com/honk/repo/-/merge_requests/2", mergeRequest)
+	require.Equal(t, map[string]string{
+		"title":         "Upgrade honk",
+		"description":   "Honk!",
+		"source_branch": "zeitgeist/new",
+		"target_branch": "main",
+	}, created)
+}
+
+func TestNewForgeErrors(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	_, _, err := newForge(deppkg.PlatformGitHub, "")
+	require.Error(t, err)
+
+	t.Setenv("GITLAB_TOKEN", "")
+	_, _, err = newForge(deppkg.PlatformGitLab, "")
+	require.Error(t, err)
+
+	_, _, err = newForge("bitbucket", "")
+	require.Error(t, err)
+}
@@ -18,11 +18,13 @@ package upstream
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	log "github.com/sirupsen/logrus"
@@ -34,6 +36,9 @@ import (
 type AMI struct {
 	Base `mapstructure:",squash"`
 
+	// Optional: AWS profile, region and role to assume
+	AWSOptions `mapstructure:",squash"`
+
 	// Either owner alias (e.g. "amazon") or owner id
 	Owner string
 
@@ -41,6 +46,22 @@ type AMI struct {
 	// Supports wilcards
 	Name string
 
+	// Optional: comma-separated regions, e.g. us-east-1,eu-west-1
+	// If set, the version holds the latest image ID for each region, e.g.
+	// us-east-1=ami-0123,eu-west-1=ami-4567, and refPaths select the
+	// region with their key
+	Regions string
+
+	// Optional: architecture, e.g. x86_64 or arm64
+	Architecture string
+
+	// Optional: additional filters, as used in --filter, one per line in the
+	// form name=value1,value2, e.g. virtualization-type=hvm or tag:Team=infra
+	Filters string
+
+	// Optional: include deprecated images
+	IncludeDeprecated bool
+
 	// ServiceClient is the AWS client to talk to AWS API
 	ServiceClient EC2DescribeImagesAPI
 }
@@ -55,38 +76,111 @@ type EC2DescribeImagesAPI interface {
 // `~/.aws/config` and `~/.aws/credentials` files, and support environment variables.
 // See AWS documentation for more details:
 // https://docs.aws.amazon.com/sdk-for-go/v1/developer-guide/sessions.html
-func NewAWSClient() *ec2.Client {
-	// Create a new session based on shared / env credentials
-	cfg, err := config.LoadDefaultConfig(context.Background())
+//
+// The profile, region and role to assume can be overridden with options.
+func NewAWSClient(options AWSOptions) (*ec2.Client, error) {
+	cfg, err := LoadAWSConfig(context.Background(), options)
 	if err != nil {
-		log.Fatal("failed to load aws config", err)
+		return nil, err
 	}
 
-	return ec2.NewFromConfig(cfg)
+	return ec2.NewFromConfig(cfg), nil
 }
 
 // LatestVersion returns the latest version of an AMI.
 //
 // Returns the latest ami id (e.g. `ami-1234567`) from all AMIs matching the predicates, sorted by CreationDate.
+// If Regions is set, returns the latest ami id for each region instead (e.g. `us-east-1=ami-1234567,eu-west-1=ami-89abcde`).
 //
 // If images cannot be listed, or if no image matches the predicates, it will return an error instead.
 func (upstream AMI) LatestVersion() (string, error) {
 	log.Debug("Using AMI upstream")
 
-	// Generate filters based on configuration
-	var filters []types.Filter
-	filters = append(filters, types.Filter{
-		Name:   aws.String("name"),
-		Values: []string{upstream.Name},
-	})
+	input, err := upstream.describeImagesInput()
+	if err != nil {
+		return "", err
+	}
+
+	var regions []string
+	for _, region := range strings.Split(upstream.Regions, ",") {
+		if region = strings.TrimSpace(region); region != "" {
+			regions = append(regions, region)
+		}
+	}
+
+	if len(regions) == 0 {
+		return upstream.latestImageID(input)
+	}
+
+	imageIDs := make([]string, 0, len(regions))
+	for _, region := range regions {
+		log.Debugf("Looking for AMIs in region %s", region)
+		imageID, err := upstream.latestImageID(input, func(o *ec2.Options) {
+			o.Region = region
+		})
+		if err != nil {
+			return "", fmt.Errorf("region %s: %w", region, err)
+		}
+
+		imageIDs = append(imageIDs, region+"="+imageID)
+	}
+
+	return strings.Join(imageIDs, ","), nil
+}
+
+// describeImagesInput generates the DescribeImages input based on the
+// configuration.
+func (upstream *AMI) describeImagesInput() (*ec2.DescribeImagesInput, error) {
+	filters := []types.Filter{
+		{
+			Name:   aws.String("name"),
+			Values: []string{upstream.Name},
+		},
+	}
+
+	if upstream.Architecture != "" {
+		filters = append(filters, types.Filter{
+			Name:   aws.String("architecture"),
+			Values: []string{upstream.Architecture},
+		})
+	}
+
+	for _, filter := range strings.Split(upstream.Filters, "\n") {
+		filter = strings.TrimSpace(filter)
+		if filter == "" {
+			continue
+		}
+
+		name, values, found := strings.Cut(filter, "=")
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid ami upstream: filter %q should be in the form name=value1,value2", filter)
+		}
+
+		filters = append(filters, types.Filter{
+			Name:   aws.String(strings.TrimSpace(name)),
+			Values: strings.Split(values, ","),
+		})
+	}
 
 	input := &ec2.DescribeImagesInput{
 		Owners:  []string{upstream.Owner},
 		Filters: filters,
 	}
 
+	if upstream.IncludeDeprecated {
+		input.IncludeDeprecated = aws.Bool(true)
+	}
+
+	return input, nil
+}
+
+func (upstream *AMI) latestImageID(input *ec2.DescribeImagesInput, optFns ...func(*ec2.Options)) (string, error) {
+	if upstream.ServiceClient == nil {
+		return "", errors.New("no AWS client configured for AMI upstream")
+	}
+
 	// Do the actual API call
-	result, err := upstream.ServiceClient.DescribeImages(context.Background(), input)
+	result, err := upstream.ServiceClient.DescribeImages(context.Background(), input, optFns...)
 	if err != nil {
 		return "", err
 	}
@@ -97,12 +191,16 @@ func (upstream AMI) LatestVersion() (string, error) {
 	sort.Slice(images, func(i, j int) bool { return *images[i].CreationDate > *images[j].CreationDate })
 	log.Debugf("Matched AMIs:\n%v", images)
 
-	if len(images) < 1 {
-		return "", fmt.Errorf("no AMI found for upstream %s", upstream.Name)
-	}
+	for _, image := range images {
+		created, err := time.Parse(time.RFC3339, aws.ToString(image.CreationDate))
+		if err == nil && upstream.tooRecent(created) {
+			log.Debugf("Skipping AMI created less than %s ago: %s (%s)", upstream.MinAge, aws.ToString(image.ImageId), created)
+			continue
+		}
 
-	latestImage := images[0]
-	log.Debugf("Latest AMI ID: %v\n", *latestImage.ImageId)
+		log.Debugf("Latest AMI ID: %v\n", aws.ToString(image.ImageId))
+		return aws.ToString(image.ImageId), nil
+	}
 
-	return *latestImage.ImageId, nil
+	return "", fmt.Errorf("no AMI found for upstream %s", upstream.Name)
 }
@@ -19,6 +19,7 @@ package upstream
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -75,6 +76,32 @@ func TestGetAMI(t *testing.T) {
 			Expected:      "ami-honk",
 			ExpectedError: false,
 		},
+		{
+			Name: "AMI younger than minAge",
+			Input: AMI{
+				Base:  Base{MinAge: 7 * 24 * time.Hour},
+				Owner: "amazon",
+				Name:  "amazon-eks-node-1.13-*",
+			},
+			Client: func(_ *testing.T) mockEc2Api {
+				return mockEc2Api(func(_ context.Context, _ *ec2.DescribeImagesInput, _ ...func(*ec2.Options)) (*ec2.DescribeImagesOutput, error) {
+					return &ec2.DescribeImagesOutput{
+						Images: []types.Image{
+							{
+								CreationDate: aws.String("2019-05-10T13:17:12.000Z"),
+								ImageId:      aws.String("ami-honk"),
+							},
+							{
+								CreationDate: aws.String(time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)),
+								ImageId:      aws.String("ami-too-recent"),
+							},
+						},
+					}, nil
+				})
+			},
+			Expected:      "ami-honk",
+			ExpectedError: false,
+		},
 		{
 			Name: "AMI does not exist",
 			Input: AMI{
@@ -107,3 +134,59 @@ func TestGetAMI(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAMIPerRegion(t *testing.T) {
+	images := map[string][]types.Image{
+		"us-east-1": {
+			{CreationDate: aws.String("2019-05-10T13:17:12.000Z"), ImageId: aws.String("ami-use1-old")},
+			{CreationDate: aws.String("2019-05-12T13:17:12.000Z"), ImageId: aws.String("ami-use1-new")},
+		},
+		"eu-west-1": {
+			{CreationDate: aws.String("2019-05-11T13:17:12.000Z"), ImageId: aws.String("ami-euw1-new")},
+		},
+	}
+
+	var inputs []*ec2.DescribeImagesInput
+	client := mockEc2Api(func(_ context.Context, params *ec2.DescribeImagesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeImagesOutput, error) {
+		inputs = append(inputs, params)
+
+		options := ec2.Options{}
+		for _, fn := range optFns {
+			fn(&options)
+		}
+
+		return &ec2.DescribeImagesOutput{Images: images[options.Region]}, nil
+	})
+
+	a := AMI{
+		Owner:             "amazon",
+		Name:              "amazon-eks-node-1.30-*",
+		Regions:           "us-east-1, eu-west-1",
+		Architecture:      "arm64",
+		Filters:           "virtualization-type=hvm\ntag:Team=infra,platform\n",
+		IncludeDeprecated: true,
+		ServiceClient:     client,
+	}
+
+	latestImages, err := a.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "us-east-1=ami-use1-new,eu-west-1=ami-euw1-new", latestImages)
+
+	require.Len(t, inputs, 2)
+	require.Equal(t, []string{"amazon"}, inputs[0].Owners)
+	require.True(t, *inputs[0].IncludeDeprecated)
+	require.Equal(t, []types.Filter{
+		{Name: aws.String("name"), Values: []string{"amazon-eks-node-1.30-*"}},
+		{Name: aws.String("architecture"), Values: []string{"arm64"}},
+		{Name: aws.String("virtualization-type"), Values: []string{"hvm"}},
+		{Name: aws.String("tag:Team"), Values: []string{"infra", "platform"}},
+	}, inputs[0].Filters)
+
+	a.Regions = "us-east-1,ap-south-1"
+	_, err = a.LatestVersion()
+	require.EqualError(t, err, "region ap-south-1: no AMI found for upstream amazon-eks-node-1.30-*")
+
+	a.Filters = "virtualization-type"
+	_, err = a.LatestVersion()
+	require.Error(t, err)
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstream
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	log "github.com/sirupsen/logrus"
+)
+
+// AWSOptions configures the AWS client used by AWS upstreams.
+//
+// Unset options fall back to the standard AWS configuration: the
+// `~/.aws/config` and `~/.aws/credentials` files, and environment variables.
+type AWSOptions struct {
+	// Optional: profile from the shared AWS configuration files
+	Profile string
+
+	// Optional: region, e.g. us-east-1
+	Region string
+
+	// Optional: ARN of a role to assume, e.g. arn:aws:iam::123456789012:role/zeitgeist
+	RoleARN string
+}
+
+// LoadAWSConfig loads the AWS configuration for the given options.
+func LoadAWSConfig(ctx context.Context, options AWSOptions) (aws.Config, error) {
+	var optFns []func(*config.LoadOptions) error
+	if options.Profile != "" {
+		optFns = append(optFns, config.WithSharedConfigProfile(options.Profile))
+	}
+
+	if options.Region != "" {
+		optFns = append(optFns, config.WithRegion(options.Region))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, optFns...)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("loading AWS config: %w", err)
+	}
+
+	if options.RoleARN != "" {
+		log.Debugf("Assuming AWS role %s", options.RoleARN)
+		provider := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), options.RoleARN, func(o *stscreds.AssumeRoleOptions) {
+			o.RoleSessionName = "zeitgeist"
+		})
+		cfg.Credentials = aws.NewCredentialsCache(provider)
+	}
+
+	return cfg, nil
+}
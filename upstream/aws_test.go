@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstream
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// setupAWSConfig points the AWS SDK at a configuration file with a single
+// honk profile, and no credentials.
+func setupAWSConfig(t *testing.T) {
+	t.Helper()
+
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config")
+	require.NoError(t, os.WriteFile(configFile, []byte("[profile honk]\nregion = eu-west-1\n"), 0o600))
+
+	t.Setenv("AWS_CONFIG_FILE", configFile)
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+}
+
+func TestUnserialiseAWSOptions(t *testing.T) {
+	var u AMI
+
+	err := yaml.Unmarshal([]byte("flavour: ami\nowner: amazon\nname: honk-*\nprofile: honk\nregion: us-east-1\nrolearn: arn:aws:iam::123456789012:role/honk"), &u)
+	require.NoError(t, err)
+}
+
+func TestLoadAWSConfig(t *testing.T) {
+	setupAWSConfig(t)
+
+	cfg, err := LoadAWSConfig(context.Background(), AWSOptions{Profile: "honk"})
+	require.NoError(t, err)
+	require.Equal(t, "eu-west-1", cfg.Region)
+
+	cfg, err = LoadAWSConfig(context.Background(), AWSOptions{Profile: "honk", Region: "us-east-1"})
+	require.NoError(t, err)
+	require.Equal(t, "us-east-1", cfg.Region)
+
+	cfg, err = LoadAWSConfig(context.Background(), AWSOptions{Region: "us-east-1", RoleARN: "arn:aws:iam::123456789012:role/honk"})
+	require.NoError(t, err)
+	require.NotNil(t, cfg.Credentials)
+
+	_, err = LoadAWSConfig(context.Background(), AWSOptions{Profile: "profile-that-does-not-exist"})
+	require.Error(t, err)
+}
+
+func TestNewAWSClient(t *testing.T) {
+	setupAWSConfig(t)
+
+	client, err := NewAWSClient(AWSOptions{Region: "ap-south-1"})
+	require.NoError(t, err)
+	require.Equal(t, "ap-south-1", client.Options().Region)
+
+	_, err = NewAWSClient(AWSOptions{Profile: "profile-that-does-not-exist"})
+	require.Error(t, err)
+}
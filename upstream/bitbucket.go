@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstream
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/zeitgeist/pkg/bitbucket"
+)
+
+// Bitbucket upstream representation.
+type Bitbucket struct {
+	Base `mapstructure:",squash"`
+
+	// Optional: Bitbucket API server, defaults to https://api.bitbucket.org
+	Server string
+
+	// Optional: environment variable holding the API token, defaults to BITBUCKET_TOKEN
+	TokenEnv string
+
+	// Repository, e.g. atlassian/python-bitbucket
+	URL string
+
+	// Optional: semver constraints, e.g. < 2.0.0
+	// Will have no effect if the dependency does not follow Semver
+	Constraints string
+
+	// If branch is specified, the version should be a commit SHA
+	// Will look for new commits on the branch
+	Branch string
+}
+
+// LatestVersion returns the highest tag for the given repository (depending
+// on the Constraints if set), as Bitbucket Cloud has no concept of releases.
+//
+// To authenticate your requests, use the BITBUCKET_TOKEN environment variable,
+// or the one set in TokenEnv.
+func (upstream Bitbucket) LatestVersion() (string, error) { //nolint:gocritic
+	log.Debug("Using Bitbucket flavour")
+	return latestBitbucketVersion(&upstream)
+}
+
+func latestBitbucketVersion(upstream *Bitbucket) (string, error) {
+	if !strings.Contains(upstream.URL, "/") {
+		return "", fmt.Errorf(
+			"invalid bitbucket repo: %s\nBitbucket repo should be in the form workspace/repo e.g., atlassian/python-bitbucket",
+			upstream.URL,
+		)
+	}
+
+	client := bitbucket.New(upstream.Server, upstream.TokenEnv)
+
+	splitURL := strings.Split(upstream.URL, "/")
+	workspace := splitURL[0]
+	repo := splitURL[1]
+
+	if upstream.Branch == "" {
+		return latestBitbucketTag(upstream, client, workspace, repo)
+	}
+	return latestBitbucketCommit(upstream, client, workspace, repo)
+}
+
+func latestBitbucketTag(upstream *Bitbucket, client *bitbucket.Bitbucket, workspace, repo string) (string, error) {
+	semverConstraints := upstream.Constraints
+	if semverConstraints == "" {
+		// If no range is passed, just use the broadest possible range
+		semverConstraints = DefaultSemVerConstraints
+	}
+
+	expectedRange, err := semver.ParseRange(semverConstraints)
+	if err != nil {
+		return "", fmt.Errorf("invalid semver constraints range: %#v: %w", upstream.Constraints, err)
+	}
+
+	log.Debugf("Retrieving tags for %s/%s...", workspace, repo)
+	bitbucketTags, err := client.ListTags(workspace, repo)
+	if err != nil {
+		return "", fmt.Errorf("retrieving Bitbucket tags: %w", err)
+	}
+
+	releaseTags := make([]Release, 0, len(bitbucketTags))
+	for _, tag := range bitbucketTags {
+		releaseTags = append(releaseTags, Release{Version: tag.Name, Published: tag.Target.Date})
+	}
+
+	tags, err := upstream.filterReleases(releaseTags)
+	if err != nil {
+		return "", fmt.Errorf("invalid bitbucket upstream: %w", err)
+	}
+
+	return selectHighestVersion(upstream.Constraints, expectedRange, tags)
+}
+
+func latestBitbucketCommit(upstream *Bitbucket, client *bitbucket.Bitbucket, workspace, repo string) (string, error) {
+	branch, err := client.GetBranch(workspace, repo, upstream.Branch)
+	if err != nil {
+		return "", fmt.Errorf("retrieving Bitbucket branch: %w", err)
+	}
+
+	return branch.Target.Hash, nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstream
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestUnserialiseBitbucket(t *testing.T) {
+	validYamls := []string{
+		"flavour: bitbucket\nurl: honk/honk\nconstraints: <1.0.0",
+		"flavour: bitbucket\nserver: https://bitbucket.example.com\nurl: honk/honk\nbranch: main",
+	}
+
+	for _, valid := range validYamls {
+		var u Bitbucket
+
+		err := yaml.Unmarshal([]byte(valid), &u)
+		require.NoError(t, err)
+	}
+}
+
+// bitbucketHandler serves a minimal subset of the Bitbucket Cloud API, with
+// tags split over two pages.
+func bitbucketHandler(rw http.ResponseWriter, req *http.Request) {
+	if req.Header.Get("Authorization") != "Bearer honk-token" {
+		rw.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch req.URL.Path {
+	case "/2.0/repositories/honk/honk/refs/tags":
+		if req.URL.Query().Get("page") == "2" {
+			fmt.Fprint(rw, `{"values": [{"name": "v1.10.0"}, {"name": "v2.0.0"}]}`)
+			return
+		}
+		fmt.Fprintf(rw, `{"values": [{"name": "v1.9.0"}, {"name": "not-a-version"}], "next": "http://%s%s?page=2"}`, req.Host, req.URL.Path)
+	case "/2.0/repositories/honk/honk/refs/branches/main":
+		fmt.Fprint(rw, `{"name": "main", "target": {"hash": "0123456789abcdef"}}`)
+	default:
+		rw.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestInvalidBitbucketValues(t *testing.T) {
+	for _, b := range []Bitbucket{
+		{URL: "honk"},
+		{Server: "http://example.com", URL: "honk/honk", Constraints: "bad-constraint"},
+	} {
+		_, err := b.LatestVersion()
+		require.Error(t, err)
+	}
+}
+
+func TestBitbucketTags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(bitbucketHandler))
+	defer server.Close()
+	t.Setenv("BITBUCKET_TOKEN", "honk-token")
+	t.Setenv("BITBUCKET_USERNAME", "")
+
+	b := Bitbucket{
+		Server: server.URL,
+		URL:    "honk/honk",
+	}
+
+	latestVersion, err := b.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "v2.0.0", latestVersion)
+
+	b.Constraints = "< 2.0.0"
+	latestVersion, err = b.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "v1.10.0", latestVersion)
+}
+
+func TestBitbucketBranch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(bitbucketHandler))
+	defer server.Close()
+	t.Setenv("BITBUCKET_TOKEN", "honk-token")
+	t.Setenv("BITBUCKET_USERNAME", "")
+
+	b := Bitbucket{
+		Server: server.URL,
+		URL:    "honk/honk",
+		Branch: "main",
+	}
+
+	latestVersion, err := b.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "0123456789abcdef", latestVersion)
+
+	b.Branch = "branch_that_does_not_exist"
+	_, err = b.LatestVersion()
+	require.Error(t, err)
+}
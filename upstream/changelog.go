@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstream
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"gopkg.in/yaml.v3"
+
+	"sigs.k8s.io/zeitgeist/pkg/container"
+)
+
+// ReleaseNotes collects the release notes of the versions seen by an upstream
+// while looking for the latest version, for flavours which know them.
+type ReleaseNotes struct {
+	notes map[string]string
+}
+
+// ReleaseNote is the release notes of a version, in Markdown.
+type ReleaseNote struct {
+	Version string
+	Notes   string
+}
+
+// NewReleaseNotes creates an empty collection of release notes.
+func NewReleaseNotes() *ReleaseNotes {
+	return &ReleaseNotes{notes: map[string]string{}}
+}
+
+func (n *ReleaseNotes) record(version, notes string) {
+	if n == nil || strings.TrimSpace(notes) == "" {
+		return
+	}
+	n.notes[version] = notes
+}
+
+// Between returns the release notes of the versions newer than current, up to
+// latest included, newest first. Versions which do not follow semver are
+// left out.
+func (n *ReleaseNotes) Between(current, latest string) []ReleaseNote {
+	if n == nil {
+		return nil
+	}
+
+	current, _, _ = strings.Cut(current, container.DigestSeparator)
+	latest, _, _ = strings.Cut(latest, container.DigestSeparator)
+
+	from, err := semver.ParseTolerant(current)
+	if err != nil {
+		return nil
+	}
+	to, err := semver.ParseTolerant(latest)
+	if err != nil {
+		return nil
+	}
+
+	type parsedNote struct {
+		parsed semver.Version
+		note   ReleaseNote
+	}
+	var notes []parsedNote
+	for version, body := range n.notes {
+		parsed, err := semver.ParseTolerant(version)
+		if err != nil || !parsed.GT(from) || parsed.GT(to) {
+			continue
+		}
+		notes = append(notes, parsedNote{parsed: parsed, note: ReleaseNote{Version: version, Notes: body}})
+	}
+
+	sort.Slice(notes, func(i, j int) bool { return notes[i].parsed.GT(notes[j].parsed) })
+
+	between := make([]ReleaseNote, 0, len(notes))
+	for _, note := range notes {
+		between = append(between, note.note)
+	}
+	return between
+}
+
+// chartChange is an entry of the artifacthub.io/changes annotation of Helm
+// charts, either a plain string or a kind and description.
+type chartChange struct {
+	Kind        string `yaml:"kind"`
+	Description string `yaml:"description"`
+}
+
+// chartChanges renders the artifacthub.io/changes annotation of a Helm chart
+// as a Markdown list.
+func chartChanges(annotation string) string {
+	var raw []yaml.Node
+	if err := yaml.Unmarshal([]byte(annotation), &raw); err != nil {
+		return ""
+	}
+
+	var changes strings.Builder
+	for i := range raw {
+		var description string
+		if err := raw[i].Decode(&description); err == nil {
+			fmt.Fprintf(&changes, "- %s\n", description)
+			continue
+		}
+
+		var change chartChange
+		if err := raw[i].Decode(&change); err != nil || change.Description == "" {
+			continue
+		}
+		if change.Kind == "" {
+			fmt.Fprintf(&changes, "- %s\n", change.Description)
+		} else {
+			fmt.Fprintf(&changes, "- **%s**: %s\n", change.Kind, change.Description)
+		}
+	}
+
+	return changes.String()
+}
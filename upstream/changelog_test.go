@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstream
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseNotesBetween(t *testing.T) {
+	notes := NewReleaseNotes()
+	notes.record("v1.0.0", "First")
+	notes.record("v1.1.0", "Second")
+	notes.record("v1.2.0", "Third")
+	notes.record("v2.0.0", "Fourth")
+	notes.record("v1.1.1", "  ")
+	notes.record("nightly", "Not a version")
+
+	require.Equal(t, []ReleaseNote{
+		{Version: "v1.2.0", Notes: "Third"},
+		{Version: "v1.1.0", Notes: "Second"},
+	}, notes.Between("v1.0.0@sha256:0123", "1.2.0"))
+
+	require.Empty(t, notes.Between("v2.0.0", "v2.0.0"))
+	require.Nil(t, notes.Between("latest", "v2.0.0"))
+
+	var none *ReleaseNotes
+	none.record("v1.0.0", "First")
+	require.Nil(t, none.Between("v0.1.0", "v1.0.0"))
+}
+
+func TestFilteredReleaseNotes(t *testing.T) {
+	u := Base{
+		CurrentVersion: "v1.0.0",
+		IgnoreVersions: []string{"v1.1.0"},
+		ReleaseNotes:   NewReleaseNotes(),
+	}
+
+	versions, err := u.filterReleases([]Release{
+		{Version: "v1.0.0", Notes: "First"},
+		{Version: "v1.1.0", Notes: "Ignored"},
+		{Version: "v1.2.0-rc.1", Notes: "Pre-release"},
+		{Version: "v1.2.0", Notes: "Latest"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"v1.0.0", "v1.2.0"}, versions)
+
+	// Ignored versions and pre-releases are left out of the notes too
+	require.Equal(t, []ReleaseNote{
+		{Version: "v1.2.0", Notes: "Latest"},
+	}, u.ReleaseNotes.Between(u.CurrentVersion, "v1.2.0"))
+}
+
+func TestChartChanges(t *testing.T) {
+	require.Equal(t, "- Plain change\n- **added**: New value\n- Kindless change\n", chartChanges(`
+- Plain change
+- kind: added
+  description: New value
+- description: Kindless change
+- kind: removed
+`))
+	require.Empty(t, chartChanges("{not a list"))
+	require.Empty(t, chartChanges(""))
+}
+
+func TestGiteaReleaseNotes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(giteaHandler))
+	defer server.Close()
+	t.Setenv("GITEA_TOKEN", "honk-token")
+
+	g := Gitea{
+		Base:   Base{CurrentVersion: "v1.2.0", ReleaseNotes: NewReleaseNotes()},
+		Server: server.URL,
+		URL:    "honk/releases",
+	}
+
+	latest, err := g.LatestVersion()
+	require.NoError(t, err)
+
+	// Drafts are left out
+	require.Equal(t, []ReleaseNote{
+		{Version: "v1.2.1", Notes: "## Fixes\n\n- Fix honking"},
+	}, g.ReleaseNotes.Between(g.CurrentVersion, latest))
+}
+
+func TestHelmReleaseNotes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(helmHandler))
+	defer server.Close()
+
+	h := Helm{
+		Base:  Base{ReleaseNotes: NewReleaseNotes()},
+		Repo:  server.URL,
+		Chart: "dependency",
+	}
+
+	latest, err := h.LatestVersion()
+	require.NoError(t, err)
+
+	require.Equal(t, []ReleaseNote{
+		{Version: "0.2.0", Notes: "- **changed**: Breaking: values.image is now values.image.repository\n- Upgrade app to 1.2.0\n"},
+		{Version: "0.1.2", Notes: "- **fixed**: Fix service port\n"},
+	}, h.ReleaseNotes.Between("0.1.1", latest))
+}
+
+func TestGithubMonorepoReleaseNotes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(githubEnterpriseHandler))
+	defer server.Close()
+	t.Setenv("GITHUB_TOKEN", "honk-token")
+
+	gh := Github{
+		Base:      Base{CurrentVersion: "v5.3.0", ReleaseNotes: NewReleaseNotes()},
+		Server:    server.URL,
+		URL:       "honk/monorepo",
+		TagPrefix: "kustomize/",
+	}
+
+	latest, err := gh.LatestVersion()
+	require.NoError(t, err)
+
+	// Release notes are known by version, without the tag prefix
+	require.Equal(t, []ReleaseNote{
+		{Version: "v5.4.1", Notes: "## Fixes\n\n- Fix kustomize"},
+	}, gh.ReleaseNotes.Between(gh.CurrentVersion, latest))
+}
+
+func TestGitLabMonorepoReleaseNotes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(gitlabHandler))
+	defer server.Close()
+	t.Setenv("GITLAB_PRIVATE_TOKEN", "honk-token")
+
+	gl := GitLab{
+		Base:       Base{CurrentVersion: "5.3.0", ReleaseNotes: NewReleaseNotes()},
+		Server:     server.URL + "/",
+		URL:        "honk/components",
+		TagPattern: `^kustomize/v(.+)$`,
+	}
+
+	latest, err := gl.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "5.4.1", latest)
+
+	// Release notes are known by the version captured by the pattern
+	require.Equal(t, []ReleaseNote{
+		{Version: "5.4.1", Notes: "## Fixes\n\n- Fix kustomize"},
+	}, gl.ReleaseNotes.Between(gl.CurrentVersion, latest))
+}
@@ -17,11 +17,16 @@ limitations under the License.
 package upstream
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"regexp"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/blang/semver/v4"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/zeitgeist/pkg/container"
@@ -35,18 +40,124 @@ type Container struct {
 	// Optional: semver constraints, e.g. < 2.0.0
 	// Will have no effect if the dependency does not follow Semver
 	Constraints string
+	// Optional: pin the image digest along with the tag
+	// Versions are then in the form tag@sha256:digest, and every refPath must
+	// reference the image by both tag and digest
+	PinDigest bool
+	// Optional: only consider tags matching this regular expression,
+	// e.g. ^\d+\.\d+\.\d+-alpine$
+	TagPattern string
+	// Optional: only consider tags with this variant suffix, e.g. alpine for
+	// 1.25.3-alpine, or "current" to keep the variant of the current version
+	Variant string
+	// Optional: environment variables holding the registry username and
+	// password, defaults to REGISTRY_USERNAME and REGISTRY_USER_PASSWORD
+	// Without credentials, the Docker config file and its credential helpers
+	// are used
+	UsernameEnv string
+	PasswordEnv string
+	// Optional: comma-separated platforms every tag must provide, e.g.
+	// linux/amd64,linux/arm64
+	// Tags missing any of them are skipped
+	Platforms string
+}
+
+const (
+	// ContainerVariantCurrent keeps the variant of the current version.
+	ContainerVariantCurrent = "current"
+)
+
+// containerTagRegex splits an image tag into its version, including an
+// optional pre-release, and an optional variant, e.g. 1.26.0-rc.1-alpine.
+var containerTagRegex = regexp.MustCompile(
+	`^(v?\d+(?:\.\d+)*(?:-(?i:alpha|beta|rc|pre|preview|dev)[.\d]*)?)(?:-(.+))?$`,
+)
+
+// ContainerTagVariant returns the variant of an image tag, e.g. alpine for
+// 1.25.3-alpine, or an empty string if the tag has no variant.
+func ContainerTagVariant(tag string) string {
+	_, variant := splitContainerTag(tag)
+	return variant
+}
+
+func splitContainerTag(tag string) (version, variant string) {
+	tag, _, _ = strings.Cut(tag, container.DigestSeparator)
+
+	matches := containerTagRegex.FindStringSubmatch(tag)
+	if matches == nil {
+		return tag, ""
+	}
+
+	return matches[1], matches[2]
 }
 
 // LatestVersion returns the latest tag for the given repository
-// (depending on the Constraints if set).
+// (depending on the Constraints if set), followed by its digest if PinDigest
+// is set.
 func (upstream Container) LatestVersion() (string, error) {
+	version, _, err := upstream.LatestVersionWithSkipped()
+	return version, err
+}
+
+// LatestVersionWithSkipped returns the latest version as LatestVersion does,
+// along with the newer tags which were skipped for not providing all the
+// required Platforms.
+func (upstream Container) LatestVersionWithSkipped() (string, []SkippedVersion, error) {
 	log.Debug("Using Container flavour")
-	return highestSemanticImageTag(&upstream)
+
+	client, err := newContainerClient(&upstream)
+	if err != nil {
+		return "", nil, err
+	}
+
+	tag, skipped, err := highestSemanticImageTag(&upstream, client)
+	if err != nil || !upstream.PinDigest {
+		return tag, skipped, err
+	}
+
+	ref := upstream.Registry + ":" + tag
+	log.Debugf("Retrieving digest for %s...", ref)
+	digest, err := client.Digest(ref)
+	if err != nil {
+		return "", nil, fmt.Errorf("retrieving Container digest: %w", err)
+	}
+
+	return tag + container.DigestSeparator + digest, skipped, nil
 }
 
-func highestSemanticImageTag(upstream *Container) (string, error) {
-	client := container.New()
+func newContainerClient(upstream *Container) (*container.Container, error) {
+	client, err := newRegistryClient(upstream.Registry, upstream.UsernameEnv, upstream.PasswordEnv)
+	if err != nil {
+		return nil, fmt.Errorf("invalid container upstream: %w", err)
+	}
+	return client, nil
+}
+
+// newRegistryClient returns a container client authenticated with the
+// credentials from the given environment variables, which must be set
+// together, or from $REGISTRY_USERNAME and $REGISTRY_USER_PASSWORD if neither
+// is set. Without credentials, those of the Docker config file are used.
+func newRegistryClient(registry, usernameEnv, passwordEnv string) (*container.Container, error) {
+	if usernameEnv == "" && passwordEnv == "" {
+		return container.New(), nil
+	}
+
+	if usernameEnv == "" || passwordEnv == "" {
+		return nil, errors.New("usernameEnv and passwordEnv must be set together")
+	}
+
+	client := container.NewFromEnv(usernameEnv, passwordEnv)
+	if client.Auth.Username == "" || client.Auth.Password == "" {
+		log.Warnf(
+			"No credentials found in %s and %s for %s, falling back to the Docker config file",
+			usernameEnv, passwordEnv, registry,
+		)
+	}
+
+	return client, nil
+}
 
+func highestSemanticImageTag(upstream *Container, client container.Client) (string, []SkippedVersion, error) {
 	semverConstraints := upstream.Constraints
 	if semverConstraints == "" {
 		// If no range is passed, just use the broadest possible range
@@ -54,13 +165,42 @@ func highestSemanticImageTag(upstream *Container) (string, error) {
 	}
 	expectedRange, err := semver.ParseRange(semverConstraints)
 	if err != nil {
-		return "", fmt.Errorf("invalid semver constraints range: %v: %w", upstream.Constraints, err)
+		return "", nil, fmt.Errorf("invalid semver constraints range: %v: %w", upstream.Constraints, err)
+	}
+
+	var tagPattern *regexp.Regexp
+	if upstream.TagPattern != "" {
+		tagPattern, err = regexp.Compile(upstream.TagPattern)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid container upstream: tagPattern: %w", err)
+		}
+	}
+
+	// Variant suffixes such as -alpine are not pre-releases
+	currentVersion, _ := splitContainerTag(upstream.CurrentVersion)
+	if upstream.History != nil {
+		upstream.History.current = currentVersion
+	}
+	includePrereleases, err := upstream.includePrereleases(isPrerelease(currentVersion))
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid container upstream: %w", err)
+	}
+
+	platforms, err := parsePlatforms(upstream.Platforms)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid container upstream: platforms: %w", err)
+	}
+
+	variant := upstream.Variant
+	if variant == ContainerVariantCurrent {
+		variant = ContainerTagVariant(upstream.CurrentVersion)
+		log.Debugf("Using variant %q of current version %s", variant, upstream.CurrentVersion)
 	}
 
 	log.Debugf("Retrieving tags for %s...", upstream.Registry)
 	tags, err := client.ListTags(upstream.Registry)
 	if err != nil {
-		return "", fmt.Errorf("retrieving Container tags: %w", err)
+		return "", nil, fmt.Errorf("retrieving Container tags: %w", err)
 	}
 	log.Debugf("Found %d tags for %s...", len(tags), upstream.Registry)
 
@@ -72,12 +212,45 @@ func highestSemanticImageTag(upstream *Container) (string, error) {
 	}
 	versions := make([]semverWithOrig, 0, len(tags))
 	for _, tag := range tags {
-		parsed, err := semver.ParseTolerant(tag)
+		if tagPattern != nil && !tagPattern.MatchString(tag) {
+			log.Debugf("Skipping tag not matching pattern (%s): %s", upstream.TagPattern, tag)
+			continue
+		}
+
+		version, tagVariant := splitContainerTag(tag)
+		if upstream.Variant != "" && tagVariant != variant {
+			log.Debugf("Skipping tag not matching variant (%s): %s", variant, tag)
+			continue
+		}
+
+		// Ignoring a version ignores all its variants
+		if upstream.Ignores(tag) || upstream.Ignores(version) {
+			log.Debugf("Skipping ignored tag: %s", tag)
+			continue
+		}
+
+		// Tags of a single variant are compared on their version alone
+		compared := tag
+		if upstream.Variant != "" {
+			compared = version
+		}
+
+		parsed, err := semver.ParseTolerant(compared)
 		if err != nil {
 			log.Debugf("Error parsing version %s (%v) as semver", tag, err)
 			continue
 		}
-		_, err = semver.Parse(tag)
+
+		// The version part of a tag only has a hyphen before a pre-release,
+		// variant suffixes such as -alpine are not pre-releases
+		if strings.Contains(version, "-") && !includePrereleases {
+			log.Debugf("Skipping pre-release tag: %s", tag)
+			continue
+		}
+
+		upstream.History.recordVersions(version)
+
+		_, err = semver.Parse(compared)
 		versions = append(versions, semverWithOrig{
 			orig:   tag,
 			parsed: parsed,
@@ -95,14 +268,104 @@ func highestSemanticImageTag(upstream *Container) (string, error) {
 	})
 
 	// find first version matching constraints
+	var skipped []SkippedVersion
 	for _, version := range versions {
 		if !expectedRange(version.parsed) {
 			log.Debugf("Skipping release not matching range constraints (%s): %s", upstream.Constraints, version.parsed.String())
 			continue
 		}
+
+		if upstream.MinAge > 0 {
+			created, err := imageCreated(client, upstream.Registry+":"+version.orig)
+			if err != nil {
+				log.Debugf("Cannot retrieve the creation time of tag %s, ignoring minAge: %v", version.orig, err)
+			} else if upstream.tooRecent(created) {
+				reason := fmt.Sprintf("published less than %s ago (%s)", upstream.MinAge, created.Format(time.RFC3339))
+				log.Debugf("Skipping tag %s: %s", version.orig, reason)
+				skipped = append(skipped, SkippedVersion{Version: version.orig, Reason: reason})
+				continue
+			}
+		}
+
+		if len(platforms) > 0 {
+			missing, err := missingPlatforms(client, upstream.Registry+":"+version.orig, platforms)
+			if err != nil {
+				return "", nil, fmt.Errorf("retrieving Container platforms: %w", err)
+			}
+
+			if len(missing) > 0 {
+				reason := "missing platforms " + strings.Join(missing, ",")
+				log.Debugf("Skipping tag %s: %s", version.orig, reason)
+				skipped = append(skipped, SkippedVersion{Version: version.orig, Reason: reason})
+				continue
+			}
+		}
 		log.Debugf("Found latest matching tag: %s", version.orig)
-		return version.orig, nil
+		return version.orig, skipped, nil
+	}
+
+	return "", skipped, errors.New("no potential tag found")
+}
+
+func parsePlatforms(platforms string) ([]*v1.Platform, error) {
+	var parsed []*v1.Platform
+	for _, platform := range strings.Split(platforms, ",") {
+		platform = strings.TrimSpace(platform)
+		if platform == "" {
+			continue
+		}
+
+		p, err := v1.ParsePlatform(platform)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, p)
+	}
+
+	return parsed, nil
+}
+
+// imageCreated returns the creation time of the image referenced by `ref`,
+// from its configuration.
+func imageCreated(client container.Client, ref string) (time.Time, error) {
+	log.Debugf("Retrieving configuration for %s...", ref)
+	raw, err := client.Config(ref)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	config, err := v1.ParseConfigFile(bytes.NewReader(raw))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing image config %s: %w", ref, err)
+	}
+
+	return config.Created.Time, nil
+}
+
+// missingPlatforms returns the required platforms not provided by the image
+// referenced by `ref`.
+func missingPlatforms(client container.Client, ref string, required []*v1.Platform) ([]string, error) {
+	log.Debugf("Retrieving platforms for %s...", ref)
+	provided, err := client.Platforms(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, want := range required {
+		found := false
+		for _, platform := range provided {
+			have, err := v1.ParsePlatform(platform)
+			if err == nil && have.Satisfies(*want) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, want.String())
+		}
 	}
 
-	return "", errors.New("no potential tag found")
+	return missing, nil
 }
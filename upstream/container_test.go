@@ -17,14 +17,30 @@ limitations under the License.
 package upstream
 
 import (
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
+
+	"sigs.k8s.io/zeitgeist/pkg/container/containerfakes"
 )
 
 func TestUnserialiseContainer(t *testing.T) {
 	validYamls := []string{
 		"flavour: container\nurl: honk/honk\nconstraints: <1.0.0",
+		"flavour: container\nurl: honk/honk\npinDigest: true",
+		"flavour: container\nurl: honk/honk\ntagPattern: ^1\\.\nvariant: alpine",
+		"flavour: container\nurl: ghcr.io/honk/honk\nusernameEnv: GHCR_USERNAME\npasswordEnv: GHCR_TOKEN",
 	}
 
 	for _, valid := range validYamls {
@@ -36,3 +52,282 @@ func TestUnserialiseContainer(t *testing.T) {
 		}
 	}
 }
+
+// newTestRegistry starts an in-memory registry, and pushes a random image for
+// each of the given tags to a test repository, which is returned.
+func newTestRegistry(t *testing.T, tags ...string) string {
+	t.Helper()
+
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(server.Close)
+
+	repo := strings.TrimPrefix(server.URL, "http://") + "/honk/honk"
+	for _, tag := range tags {
+		pushRandomImage(t, repo+":"+tag)
+	}
+
+	return repo
+}
+
+func pushRandomImage(t *testing.T, ref string) {
+	t.Helper()
+
+	img, err := random.Image(64, 1)
+	require.NoError(t, err)
+	require.NoError(t, crane.Push(img, ref))
+}
+
+func pushRandomImageCreatedAt(t *testing.T, ref string, created time.Time) {
+	t.Helper()
+
+	img, err := random.Image(64, 1)
+	require.NoError(t, err)
+	img, err = mutate.CreatedAt(img, v1.Time{Time: created})
+	require.NoError(t, err)
+	require.NoError(t, crane.Push(img, ref))
+}
+
+func TestContainerLatestVersion(t *testing.T) {
+	repo := newTestRegistry(t, "1.0.0", "v1.1.0", "1.2.0", "latest", "2.0.0")
+
+	c := Container{
+		Registry: repo,
+	}
+
+	latestVersion, err := c.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "2.0.0", latestVersion)
+
+	c.Constraints = "< 2.0.0"
+	latestVersion, err = c.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "1.2.0", latestVersion)
+}
+
+func TestContainerPinDigest(t *testing.T) {
+	repo := newTestRegistry(t, "1.0.0", "1.2.0")
+
+	c := Container{
+		Registry:  repo,
+		PinDigest: true,
+	}
+
+	digest, err := crane.Digest(repo + ":1.2.0")
+	require.NoError(t, err)
+
+	latestVersion, err := c.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "1.2.0@"+digest, latestVersion)
+
+	// Re-pushing the tag changes the digest
+	pushRandomImage(t, repo+":1.2.0")
+
+	newDigest, err := crane.Digest(repo + ":1.2.0")
+	require.NoError(t, err)
+	require.NotEqual(t, digest, newDigest)
+
+	latestVersion, err = c.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "1.2.0@"+newDigest, latestVersion)
+}
+
+func TestContainerTagVariant(t *testing.T) {
+	for tag, variant := range map[string]string{
+		"1.25.3":                    "",
+		"v1.25.3":                   "",
+		"1.25.3-alpine":             "alpine",
+		"1.25.3-alpine3.18":         "alpine3.18",
+		"1.26.0-rc.1":               "",
+		"1.26.0-rc1-alpine":         "alpine",
+		"1.25.3-bookworm-slim":      "bookworm-slim",
+		"1.25.3-alpine@sha256:0123": "alpine",
+		"latest":                    "",
+	} {
+		require.Equal(t, variant, ContainerTagVariant(tag), tag)
+	}
+}
+
+func TestHighestSemanticImageTagFiltering(t *testing.T) {
+	tags := []string{
+		"latest", "alpine",
+		"1.25.3", "1.25.3-alpine", "1.25.3-alpine-slim",
+		"1.26.0-rc.1", "1.26.0-rc.1-alpine",
+		"1.27.0", "1.27.0-alpine", "1.27.0-alpine-slim",
+		"1.28.0-beta.2", "1.28.0-beta.2-alpine",
+	}
+
+	for _, tc := range []struct {
+		name     string
+		upstream Container
+		expected string
+		err      bool
+	}{
+		{
+			name:     "defaults skip pre-releases",
+			upstream: Container{},
+			expected: "1.27.0",
+		},
+		{
+			name:     "include pre-releases",
+			upstream: Container{Base: Base{CurrentVersion: "1.27.0", Prereleases: PrereleasesInclude}, Variant: "current"},
+			expected: "1.28.0-beta.2",
+		},
+		{
+			name:     "variant",
+			upstream: Container{Variant: "alpine"},
+			expected: "1.27.0-alpine",
+		},
+		{
+			name:     "variant with pre-releases",
+			upstream: Container{Base: Base{Prereleases: PrereleasesInclude}, Variant: "alpine"},
+			expected: "1.28.0-beta.2-alpine",
+		},
+		{
+			name:     "variant with constraints",
+			upstream: Container{Base: Base{Prereleases: PrereleasesInclude}, Variant: "alpine", Constraints: "< 1.27.0"},
+			expected: "1.26.0-rc.1-alpine",
+		},
+		{
+			name:     "current pre-release keeps tracking pre-releases",
+			upstream: Container{Base: Base{CurrentVersion: "1.26.0-rc.1-alpine"}, Variant: "current"},
+			expected: "1.28.0-beta.2-alpine",
+		},
+		{
+			name:     "excluded pre-releases with a current pre-release",
+			upstream: Container{Base: Base{CurrentVersion: "1.26.0-rc.1", Prereleases: PrereleasesExclude}},
+			expected: "1.27.0",
+		},
+		{
+			name:     "current variant",
+			upstream: Container{Base: Base{CurrentVersion: "1.25.3-alpine-slim"}, Variant: "current"},
+			expected: "1.27.0-alpine-slim",
+		},
+		{
+			name:     "current variant without suffix",
+			upstream: Container{Base: Base{CurrentVersion: "1.25.3"}, Variant: "current"},
+			expected: "1.27.0",
+		},
+		{
+			name:     "ignored versions with all their variants",
+			upstream: Container{Base: Base{IgnoreVersions: []string{"1.27.0"}}, Variant: "alpine"},
+			expected: "1.25.3-alpine",
+		},
+		{
+			name:     "tag pattern",
+			upstream: Container{TagPattern: `^1\.25\.`},
+			expected: "1.25.3",
+		},
+		{
+			name:     "unknown variant",
+			upstream: Container{Variant: "bookworm"},
+			err:      true,
+		},
+		{
+			name:     "invalid tag pattern",
+			upstream: Container{TagPattern: "("},
+			err:      true,
+		},
+		{
+			name:     "invalid pre-release policy",
+			upstream: Container{Base: Base{Prereleases: "sometimes"}},
+			err:      true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &containerfakes.FakeClient{}
+			client.ListTagsReturns(tags, nil)
+
+			latestVersion, _, err := highestSemanticImageTag(&tc.upstream, client)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, latestVersion)
+		})
+	}
+}
+
+func TestHighestSemanticImageTagPlatforms(t *testing.T) {
+	client := &containerfakes.FakeClient{}
+	client.ListTagsReturns([]string{"1.0.0", "1.1.0", "1.2.0", "1.3.0"}, nil)
+	client.PlatformsCalls(func(ref string) ([]string, error) {
+		switch ref {
+		case "honk/honk:1.3.0":
+			return []string{"linux/amd64"}, nil
+		case "honk/honk:1.2.0":
+			return []string{"linux/amd64", "windows/amd64"}, nil
+		default:
+			return []string{"linux/amd64", "linux/arm64/v8"}, nil
+		}
+	})
+
+	c := Container{
+		Registry:  "honk/honk",
+		Platforms: "linux/amd64, linux/arm64",
+	}
+
+	latestVersion, skipped, err := highestSemanticImageTag(&c, client)
+	require.NoError(t, err)
+	require.Equal(t, "1.1.0", latestVersion)
+	require.Equal(t, []SkippedVersion{
+		{Version: "1.3.0", Reason: "missing platforms linux/arm64"},
+		{Version: "1.2.0", Reason: "missing platforms linux/arm64"},
+	}, skipped)
+	require.Equal(t, 3, client.PlatformsCallCount())
+
+	c.Platforms = "linux/s390x"
+	_, skipped, err = highestSemanticImageTag(&c, client)
+	require.Error(t, err)
+	require.Len(t, skipped, 4)
+
+	c.Platforms = "linux/amd64/v1/extra"
+	_, _, err = highestSemanticImageTag(&c, client)
+	require.Error(t, err)
+}
+
+func TestContainerMinAge(t *testing.T) {
+	repo := newTestRegistry(t, "1.0.0")
+	pushRandomImageCreatedAt(t, repo+":1.1.0", time.Now().Add(-30*24*time.Hour))
+	pushRandomImageCreatedAt(t, repo+":1.2.0", time.Now().Add(-time.Hour))
+
+	c := Container{
+		Base:     Base{MinAge: 7 * 24 * time.Hour},
+		Registry: repo,
+	}
+
+	latestVersion, skipped, err := c.LatestVersionWithSkipped()
+	require.NoError(t, err)
+	require.Equal(t, "1.1.0", latestVersion)
+	require.Len(t, skipped, 1)
+	require.Equal(t, "1.2.0", skipped[0].Version)
+	require.Contains(t, skipped[0].Reason, "published less than 168h0m0s ago")
+
+	c.MinAge = 0
+	latestVersion, err = c.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "1.2.0", latestVersion)
+}
+
+func TestRegistryClientCredentials(t *testing.T) {
+	t.Setenv("REGISTRY_USERNAME", "global")
+	t.Setenv("REGISTRY_USER_PASSWORD", "global-secret")
+	t.Setenv("GHCR_USERNAME", "goose")
+	t.Setenv("GHCR_TOKEN", "")
+
+	client, err := newRegistryClient("honk/honk", "", "")
+	require.NoError(t, err)
+	require.Equal(t, "global", client.Auth.Username)
+
+	// The global credentials are never mixed with per-upstream ones
+	client, err = newRegistryClient("ghcr.io/honk/honk", "GHCR_USERNAME", "GHCR_TOKEN")
+	require.NoError(t, err)
+	require.Equal(t, "goose", client.Auth.Username)
+	require.Empty(t, client.Auth.Password)
+
+	_, err = newRegistryClient("ghcr.io/honk/honk", "GHCR_USERNAME", "")
+	require.ErrorContains(t, err, "usernameEnv and passwordEnv must be set together")
+
+	_, err = Container{Registry: "ghcr.io/honk/honk", PasswordEnv: "GHCR_TOKEN"}.LatestVersion()
+	require.ErrorContains(t, err, "invalid container upstream: usernameEnv and passwordEnv must be set together")
+}
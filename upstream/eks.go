@@ -19,12 +19,13 @@ package upstream
 import (
 	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"regexp"
+	"strings"
 
 	"github.com/blang/semver/v4"
 	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/zeitgeist/pkg/endoflife"
 )
 
 // EKS is the Elastic Kubernetes Service upstream
@@ -35,56 +36,108 @@ type EKS struct {
 
 	// Optional: semver constraints, e.g. < 1.16.0
 	Constraints string
+
+	// Optional: endoflife.date API server listing the EKS releases, defaults
+	// to https://endoflife.date
+	Server string
+
+	// Optional: what to track, either "kubernetes" (default) for Kubernetes
+	// versions, e.g. 1.31.0, or "platform" for the EKS platform versions of
+	// KubernetesVersion, e.g. eks.12
+	Track string
+
+	// Kubernetes version of the platform versions to track, e.g. 1.31
+	// Required when tracking platform versions
+	KubernetesVersion string
 }
 
+const (
+	// EKSTrackKubernetes tracks EKS Kubernetes versions.
+	EKSTrackKubernetes = "kubernetes"
+
+	// EKSTrackPlatform tracks EKS platform versions.
+	EKSTrackPlatform = "platform"
+
+	// EKSProduct is the name of EKS on endoflife.date.
+	EKSProduct = "amazon-eks"
+)
+
+// eksPlatformVersionRegex matches the platform version in the latest release
+// of a cycle, e.g. eks-12 in 1.31-eks-12.
+var eksPlatformVersionRegex = regexp.MustCompile(`eks[.-](\d+)`)
+
 // LatestVersion returns the latest available EKS version.
 //
-// Retrieves all available EKS versions from the parsing HTML from AWS's documentation page
-// This feels brittle and wrong, but AFAIK there is no better way to do this.
+// Retrieves all available EKS versions from the endoflife.date release
+// cycles of EKS. Their end of support is checked along with the end of life
+// of other dependencies, see dependency.CheckEndOfLife.
 func (upstream EKS) LatestVersion() (string, error) {
 	log.Debug("Using EKS upstream")
 
+	switch upstream.Track {
+	case "", EKSTrackKubernetes:
+	case EKSTrackPlatform:
+		if upstream.KubernetesVersion == "" {
+			return "", errors.New("invalid eks upstream: kubernetesVersion must be set to track platform versions")
+		}
+	default:
+		return "", fmt.Errorf(
+			"invalid eks upstream: track must be one of %s or %s, got %q",
+			EKSTrackKubernetes, EKSTrackPlatform, upstream.Track,
+		)
+	}
+
 	semverConstraints := upstream.Constraints
 	if semverConstraints == "" {
 		// If no range is passed, just use the broadest possible range
-		semverConstraints = ">= 0.0.0"
+		semverConstraints = DefaultSemVerConstraints
 	}
 
 	expectedRange, err := semver.ParseRange(semverConstraints)
 	if err != nil {
-		return "", fmt.Errorf("invalid semver constraints range: %v: %w", upstream.Constraints, err)
+		return "", fmt.Errorf("invalid semver constraints range: %#v: %w", upstream.Constraints, err)
 	}
 
-	const docsURL = "https://docs.aws.amazon.com/eks/latest/userguide/platform-versions.html"
-	log.Debugf("Retrieving EKS releases from  %s...", docsURL)
-
-	resp, err := http.Get(docsURL)
+	log.Debugf("Retrieving EKS releases...")
+	cycles, err := endoflife.New(upstream.Server).Cycles(EKSProduct)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("retrieving EKS releases: %w", err)
+	}
+
+	if upstream.Track == EKSTrackPlatform {
+		return latestEKSPlatformVersion(upstream.KubernetesVersion, cycles)
 	}
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
+
+	versions := make([]string, 0, len(cycles))
+	for _, cycle := range cycles {
+		versions = append(versions, cycle.Cycle+".0")
+	}
+
+	upstream.History.recordVersions(versions...)
+
+	latestVersion, err := selectHighestVersion(upstream.Constraints, expectedRange, versions)
 	if err != nil {
 		return "", err
 	}
 
-	// Versions are listed as semver within a `<code class="code">` tag
-	r := regexp.MustCompile(`<code class="code">(\d+.\d+.\d+)</code>`)
-	matches := r.FindAllSubmatch(body, -1)
-	for _, match := range matches {
-		versionString := string(match[1])
-		version, err := semver.Parse(versionString)
-		if err != nil {
-			log.Debugf("Error parsing version %v (%v) as semver, cannot validate semver constraints", versionString, err)
-		} else if !expectedRange(version) {
-			log.Debugf("Skipping version not matching range constraints (%v): %v", upstream.Constraints, versionString)
-			continue
-		}
+	// Keep the format of the current version, e.g. 1.31 rather than 1.31.0
+	if strings.Count(upstream.Base.CurrentVersion, ".") == 1 {
+		latestVersion = strings.TrimSuffix(latestVersion, ".0")
+	}
 
-		log.Debugf("Found latest matching release: %v", version)
+	return latestVersion, nil
+}
+
+func latestEKSPlatformVersion(kubernetesVersion string, cycles []*endoflife.Cycle) (string, error) {
+	cycle := endoflife.MatchCycle(cycles, kubernetesVersion)
+	if cycle == nil {
+		return "", fmt.Errorf("no EKS release found for Kubernetes %s", kubernetesVersion)
+	}
 
-		return version.String(), nil
+	matches := eksPlatformVersionRegex.FindStringSubmatch(cycle.Latest)
+	if matches == nil {
+		return "", fmt.Errorf("no EKS platform version found for Kubernetes %s in %q", cycle.Cycle, cycle.Latest)
 	}
 
-	return "", errors.New("no matching EKS version found")
+	return "eks." + matches[1], nil
 }
@@ -17,6 +17,9 @@ limitations under the License.
 package upstream
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -27,6 +30,7 @@ func TestUnserialiseEKS(t *testing.T) {
 	validYamls := []string{
 		"flavour: eks",
 		"flavour: eks\nconstraints: < 1.20.0",
+		"flavour: eks\nserver: https://example.com\ntrack: platform\nkubernetesVersion: 1.31",
 	}
 
 	for _, valid := range validYamls {
@@ -76,3 +80,72 @@ func TestEKSUnsatisfiableConstraint(t *testing.T) {
 	require.Error(t, err)
 	require.Empty(t, latestVersion)
 }
+
+// eksHandler serves the EKS release cycles of the endoflife.date API.
+func eksHandler(rw http.ResponseWriter, req *http.Request) {
+	if req.URL.Path != "/api/amazon-eks.json" {
+		rw.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	fmt.Fprint(rw, `[
+		{"cycle": "1.31", "eol": "2025-11-26", "extendedSupport": "2026-11-26", "latest": "1.31-eks-12"},
+		{"cycle": "1.30", "eol": "2025-07-23", "extendedSupport": "2026-07-23", "latest": "1.30-eks-20"},
+		{"cycle": "1.29", "eol": "2025-03-23", "extendedSupport": true, "latest": "1.29-eks-25"}
+	]`)
+}
+
+func TestEKSReleaseFeed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(eksHandler))
+	defer server.Close()
+
+	e := EKS{
+		Server: server.URL,
+	}
+
+	latestVersion, err := e.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "1.31.0", latestVersion)
+
+	e.Constraints = "< 1.31.0"
+	latestVersion, err = e.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "1.30.0", latestVersion)
+
+	// Keep the format of the current version
+	e.Base.CurrentVersion = "1.29"
+	latestVersion, err = e.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "1.30", latestVersion)
+
+	e.Server = server.URL + "/not-a-server"
+	_, err = e.LatestVersion()
+	require.Error(t, err)
+}
+
+func TestEKSPlatformVersions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(eksHandler))
+	defer server.Close()
+
+	e := EKS{
+		Server:            server.URL,
+		Track:             "platform",
+		KubernetesVersion: "1.30",
+	}
+
+	latestVersion, err := e.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "eks.20", latestVersion)
+
+	e.KubernetesVersion = "1.12"
+	_, err = e.LatestVersion()
+	require.Error(t, err)
+
+	for _, invalid := range []EKS{
+		{Server: e.Server, Track: "platform"},
+		{Server: e.Server, Track: "addons"},
+	} {
+		_, err = invalid.LatestVersion()
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstream
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/zeitgeist/pkg/gitea"
+)
+
+// Gitea upstream representation, also used for Forgejo.
+type Gitea struct {
+	Base `mapstructure:",squash"`
+
+	// Gitea or Forgejo server, e.g. https://codeberg.org
+	Server string
+
+	// Optional: environment variable holding the API token, defaults to GITEA_TOKEN
+	TokenEnv string
+
+	// Repository, e.g. forgejo/forgejo
+	URL string
+
+	// Optional: semver constraints, e.g. < 2.0.0
+	// Will have no effect if the dependency does not follow Semver
+	Constraints string
+
+	// If branch is specified, the version should be a commit SHA
+	// Will look for new commits on the branch
+	Branch string
+}
+
+// LatestVersion returns the latest non-draft Gitea Release for the given
+// repository (depending on the Constraints if set), or the latest tag if the
+// repository has no releases.
+//
+// To authenticate your requests, use the GITEA_TOKEN environment variable, or
+// the one set in TokenEnv.
+func (upstream Gitea) LatestVersion() (string, error) { //nolint:gocritic
+	log.Debug("Using Gitea flavour")
+	return latestGiteaVersion(&upstream)
+}
+
+func latestGiteaVersion(upstream *Gitea) (string, error) {
+	if upstream.Server == "" {
+		return "", errors.New("invalid gitea upstream: missing server argument")
+	}
+
+	if !strings.Contains(upstream.URL, "/") {
+		return "", fmt.Errorf(
+			"invalid gitea repo: %s\nGitea repo should be in the form owner/repo e.g., forgejo/forgejo",
+			upstream.URL,
+		)
+	}
+
+	client := gitea.New(upstream.Server, upstream.TokenEnv)
+
+	splitURL := strings.Split(upstream.URL, "/")
+	owner := splitURL[0]
+	repo := splitURL[1]
+
+	if upstream.Branch == "" {
+		return latestGiteaRelease(upstream, client, owner, repo)
+	}
+	return latestGiteaCommit(upstream, client, owner, repo)
+}
+
+func latestGiteaRelease(upstream *Gitea, client *gitea.Gitea, owner, repo string) (string, error) {
+	semverConstraints := upstream.Constraints
+	if semverConstraints == "" {
+		// If no range is passed, just use the broadest possible range
+		semverConstraints = DefaultSemVerConstraints
+	}
+
+	expectedRange, err := semver.ParseRange(semverConstraints)
+	if err != nil {
+		return "", fmt.Errorf("invalid semver constraints range: %#v: %w", upstream.Constraints, err)
+	}
+
+	log.Debugf("Retrieving repository information for %s/%s...", owner, repo)
+	repoInfo, err := client.GetRepository(owner, repo)
+	if err != nil {
+		return "", fmt.Errorf("retrieving Gitea repository: %w", err)
+	}
+
+	if repoInfo.Archived {
+		log.Warnf("Gitea repository %s/%s is archived", owner, repo)
+	}
+
+	log.Debugf("Retrieving releases for %s/%s...", owner, repo)
+	releases, err := client.Releases(owner, repo)
+	if err != nil {
+		return "", fmt.Errorf("retrieving Gitea releases: %w", err)
+	}
+
+	includePrereleases, err := upstream.includePrereleases(isPrerelease(upstream.CurrentVersion))
+	if err != nil {
+		return "", fmt.Errorf("invalid gitea upstream: %w", err)
+	}
+
+	var releaseTags []Release
+	// if there is no releases we will try to get the tags, the project might just use tags to release.
+	if len(releases) == 0 {
+		giteaTags, err := client.ListTags(owner, repo)
+		if err != nil {
+			return "", fmt.Errorf("retrieving Gitea tags: %w", err)
+		}
+
+		for _, tag := range giteaTags {
+			releaseTags = append(releaseTags, Release{Version: tag.Name, Published: tag.Commit.Created})
+		}
+	} else {
+		for _, release := range releases {
+			if release.Draft {
+				log.Debugf("Skipping draft release: %s\n", release.TagName)
+				continue
+			}
+
+			if release.Prerelease && !includePrereleases {
+				log.Debugf("Skipping pre-release: %s\n", release.TagName)
+				continue
+			}
+
+			releaseTags = append(releaseTags, Release{Version: release.TagName, Published: release.PublishedAt, Notes: release.Body})
+		}
+	}
+
+	tags, err := upstream.filterReleases(releaseTags)
+	if err != nil {
+		return "", fmt.Errorf("invalid gitea upstream: %w", err)
+	}
+
+	return selectHighestVersion(upstream.Constraints, expectedRange, tags)
+}
+
+func latestGiteaCommit(upstream *Gitea, client *gitea.Gitea, owner, repo string) (string, error) {
+	log.Debugf("Retrieving repository information for %s/%s...", owner, repo)
+	repoInfo, err := client.GetRepository(owner, repo)
+	if err != nil {
+		return "", fmt.Errorf("retrieving Gitea repository: %w", err)
+	}
+
+	if repoInfo.Archived {
+		log.Warnf("Gitea repository %s/%s is archived", owner, repo)
+	}
+
+	branch, err := client.GetBranch(owner, repo, upstream.Branch)
+	if err != nil {
+		return "", fmt.Errorf("retrieving Gitea branch: %w", err)
+	}
+
+	return branch.Commit.ID, nil
+}
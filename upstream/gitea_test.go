@@ -0,0 +1,198 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstream
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestUnserialiseGitea(t *testing.T) {
+	validYamls := []string{
+		"flavour: gitea\nserver: https://codeberg.org\nurl: forgejo/forgejo\nconstraints: <1.0.0",
+		"flavour: forgejo\nserver: https://codeberg.org\nurl: forgejo/forgejo\ntokenEnv: CODEBERG_TOKEN\nbranch: main",
+	}
+
+	for _, valid := range validYamls {
+		var u Gitea
+
+		err := yaml.Unmarshal([]byte(valid), &u)
+		require.NoError(t, err)
+	}
+}
+
+// giteaHandler serves a minimal subset of the Gitea API:
+// - honk/releases has releases, including a draft and a pre-release
+// - honk/tags has no release, only tags
+// - honk/archived is archived.
+func giteaHandler(rw http.ResponseWriter, req *http.Request) {
+	if req.Header.Get("Authorization") != "token honk-token" {
+		rw.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch req.URL.Path {
+	case "/api/v1/repos/honk/releases", "/api/v1/repos/honk/tags":
+		fmt.Fprint(rw, `{"full_name": "honk/repo", "archived": false}`)
+	case "/api/v1/repos/honk/archived":
+		fmt.Fprint(rw, `{"full_name": "honk/archived", "archived": true}`)
+	case "/api/v1/repos/honk/releases/releases", "/api/v1/repos/honk/archived/releases":
+		fmt.Fprint(rw, `[
+			{"tag_name": "v1.3.0", "draft": true, "body": "Draft notes"},
+			{"tag_name": "v2.1.0", "prerelease": true},
+			{"tag_name": "v1.2.1", "draft": false, "published_at": "2024-03-01T00:00:00Z", "body": "## Fixes\n\n- Fix honking"},
+			{"tag_name": "v2.0.0", "draft": false, "published_at": "`+time.Now().UTC().Format(time.RFC3339)+`"},
+			{"tag_name": "v1.2.0", "draft": false, "published_at": "2024-02-01T00:00:00Z"}
+		]`)
+	case "/api/v1/repos/honk/tags/releases":
+		fmt.Fprint(rw, `[]`)
+	case "/api/v1/repos/honk/tags/tags":
+		fmt.Fprint(rw, `[{"name": "0.1.0"}, {"name": "0.3.0"}, {"name": "0.2.0"}]`)
+	case "/api/v1/repos/honk/releases/branches/main":
+		fmt.Fprint(rw, `{"name": "main", "commit": {"id": "0123456789abcdef"}}`)
+	default:
+		rw.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestInvalidGiteaValues(t *testing.T) {
+	for _, g := range []Gitea{
+		{URL: "honk/releases"},
+		{Server: "http://example.com", URL: "honk"},
+		{Server: "http://example.com", URL: "honk/releases", Constraints: "bad-constraint"},
+	} {
+		_, err := g.LatestVersion()
+		require.Error(t, err)
+	}
+}
+
+func TestGiteaReleases(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(giteaHandler))
+	defer server.Close()
+	t.Setenv("GITEA_TOKEN", "honk-token")
+
+	g := Gitea{
+		Server: server.URL,
+		URL:    "honk/releases",
+	}
+
+	latestVersion, err := g.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "v2.0.0", latestVersion)
+
+	g.Constraints = "< 2.0.0"
+	latestVersion, err = g.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "v1.2.1", latestVersion)
+
+	g.URL = "honk/archived"
+	latestVersion, err = g.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "v1.2.1", latestVersion)
+}
+
+func TestGiteaTagsFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(giteaHandler))
+	defer server.Close()
+	t.Setenv("FORGEJO_TOKEN", "honk-token")
+
+	g := Gitea{
+		Server:   server.URL + "/",
+		TokenEnv: "FORGEJO_TOKEN",
+		URL:      "honk/tags",
+	}
+
+	latestVersion, err := g.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "0.3.0", latestVersion)
+}
+
+func TestGiteaBranch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(giteaHandler))
+	defer server.Close()
+	t.Setenv("GITEA_TOKEN", "honk-token")
+
+	g := Gitea{
+		Server: server.URL,
+		URL:    "honk/releases",
+		Branch: "main",
+	}
+
+	latestVersion, err := g.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "0123456789abcdef", latestVersion)
+
+	g.Branch = "branch_that_does_not_exist"
+	_, err = g.LatestVersion()
+	require.Error(t, err)
+}
+
+func TestGiteaUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(giteaHandler))
+	defer server.Close()
+	t.Setenv("GITEA_TOKEN", "")
+
+	g := Gitea{
+		Server: server.URL,
+		URL:    "honk/releases",
+	}
+
+	_, err := g.LatestVersion()
+	require.Error(t, err)
+}
+
+func TestGiteaPrereleases(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(giteaHandler))
+	defer server.Close()
+	t.Setenv("GITEA_TOKEN", "honk-token")
+
+	g := Gitea{
+		Base:   Base{CurrentVersion: "v2.0.0", Prereleases: PrereleasesInclude},
+		Server: server.URL,
+		URL:    "honk/releases",
+	}
+
+	latestVersion, err := g.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "v2.1.0", latestVersion)
+
+	g.Base = Base{Prereleases: "sometimes"}
+	_, err = g.LatestVersion()
+	require.Error(t, err)
+}
+
+func TestGiteaMinAge(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(giteaHandler))
+	defer server.Close()
+	t.Setenv("GITEA_TOKEN", "honk-token")
+
+	g := Gitea{
+		Base:   Base{MinAge: 7 * 24 * time.Hour},
+		Server: server.URL,
+		URL:    "honk/releases",
+	}
+
+	latestVersion, err := g.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "v1.2.1", latestVersion)
+}
@@ -24,12 +24,20 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-utils/env"
 )
 
 // Github upstream representation.
 type Github struct {
 	Base `mapstructure:",squash"`
 
+	// Optional: GitHub Enterprise Server, e.g. https://github.example.com,
+	// defaults to github.com
+	Server string
+
+	// Optional: environment variable holding the API token, defaults to GITHUB_TOKEN
+	TokenEnv string
+
 	// Github URL, e.g. hashicorp/terraform or helm/helm
 	URL string
 
@@ -40,6 +48,15 @@ type Github struct {
 	// If branch is specified, the version should be a commit SHA
 	// Will look for new commits on the branch
 	Branch string
+
+	// Optional: only consider tags starting with this prefix, which is stripped
+	// from the version, e.g. kustomize/ for monorepos tagging kustomize/v5.4.1
+	TagPrefix string
+
+	// Optional: only consider tags matching this regular expression, with the
+	// version captured by the `version` named group or the first group,
+	// e.g. ^kustomize/(v.*)$
+	TagPattern string
 }
 
 // LatestVersion returns the latest non-draft, non-prerelease Github Release
@@ -50,7 +67,8 @@ type Github struct {
 // The Github API allows unauthenticated requests, but the API limits are very
 // strict: https://developer.github.com/v3/#rate-limiting
 //
-// To authenticate your requests, use the GITHUB_ACCESS_TOKEN environment variable.
+// To authenticate your requests, use the GITHUB_TOKEN environment variable,
+// or the one set in TokenEnv.
 func (upstream Github) LatestVersion() (string, error) {
 	log.Debug("Using GitHub flavour")
 	return latestVersion(upstream)
@@ -63,8 +81,34 @@ func latestVersion(upstream Github) (string, error) {
 	return latestCommit(upstream)
 }
 
+// newGitHubClient returns a client for github.com, or for the GitHub
+// Enterprise Server set in the upstream.
+func newGitHubClient(upstream Github) (*github.GitHub, error) {
+	tokenEnv := upstream.TokenEnv
+	if tokenEnv == "" {
+		tokenEnv = github.TokenEnvKey
+	}
+	token := env.Default(tokenEnv, "")
+
+	if upstream.Server == "" {
+		return github.NewWithToken(token)
+	}
+
+	// The API and upload paths (/api/v3/ and /api/uploads/) are added by the
+	// client if missing
+	server := strings.TrimSuffix(upstream.Server, "/") + "/"
+	client, err := github.NewEnterpriseWithToken(server, server, token)
+	if err != nil {
+		return nil, fmt.Errorf("configuring GitHub Enterprise client for %s: %w", upstream.Server, err)
+	}
+	return client, nil
+}
+
 func latestRelease(upstream Github) (string, error) {
-	client := github.New()
+	client, err := newGitHubClient(upstream)
+	if err != nil {
+		return "", err
+	}
 
 	if !strings.Contains(upstream.URL, "/") {
 		return "", fmt.Errorf(
@@ -84,6 +128,11 @@ func latestRelease(upstream Github) (string, error) {
 		return "", fmt.Errorf("invalid semver constraints range: %#v: %w", upstream.Constraints, err)
 	}
 
+	includePrereleases, err := upstream.includePrereleases(isPrerelease(upstream.CurrentVersion))
+	if err != nil {
+		return "", fmt.Errorf("invalid github upstream: %w", err)
+	}
+
 	splitURL := strings.Split(upstream.URL, "/")
 	owner := splitURL[0]
 	repo := splitURL[1]
@@ -98,7 +147,7 @@ func latestRelease(upstream Github) (string, error) {
 		log.Warnf("GitHub repository %s/%s is archived", owner, repo)
 	}
 
-	var tags []string
+	var releaseTags []Release
 	// We'll need to fetch all releases, as Github doesn't provide sorting options.
 	// If we don't do that, we risk running into the case where for example:
 	// - Version 1.0.0 and 2.0.0 exist
@@ -106,7 +155,7 @@ func latestRelease(upstream Github) (string, error) {
 	//
 	// Now the "latest" (date-wise) release is not the highest semver, and not necessarily the one we want
 	log.Debugf("Retrieving releases for %s/%s...", owner, repo)
-	releases, err := client.Releases(owner, repo, false)
+	releases, err := client.Releases(owner, repo, includePrereleases)
 	if err != nil {
 		return "", fmt.Errorf("retrieving GitHub releases: %w", err)
 	}
@@ -119,7 +168,7 @@ func latestRelease(upstream Github) (string, error) {
 		}
 
 		for _, tag := range gitHubTags {
-			tags = append(tags, tag.GetName())
+			releaseTags = append(releaseTags, Release{Version: tag.GetName()})
 		}
 	} else {
 		for _, release := range releases {
@@ -132,15 +181,32 @@ func latestRelease(upstream Github) (string, error) {
 				continue
 			}
 
-			tags = append(tags, release.GetTagName())
+			releaseTags = append(releaseTags, Release{
+				Version:   release.GetTagName(),
+				Published: release.GetPublishedAt().Time,
+				Notes:     release.GetBody(),
+			})
 		}
 	}
 
-	return selectHighestVersion(upstream.Constraints, expectedRange, tags)
+	releaseTags, err = componentReleases(upstream.TagPrefix, upstream.TagPattern, releaseTags)
+	if err != nil {
+		return "", fmt.Errorf("invalid github upstream: %w", err)
+	}
+
+	versions, err := upstream.filterReleases(releaseTags)
+	if err != nil {
+		return "", fmt.Errorf("invalid github upstream: %w", err)
+	}
+
+	return selectHighestVersion(upstream.Constraints, expectedRange, versions)
 }
 
 func latestCommit(upstream Github) (string, error) {
-	client := github.New()
+	client, err := newGitHubClient(upstream)
+	if err != nil {
+		return "", err
+	}
 
 	if !strings.Contains(upstream.URL, "/") {
 		return "", fmt.Errorf(
@@ -17,6 +17,9 @@ limitations under the License.
 package upstream
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/blang/semver/v4"
@@ -27,6 +30,7 @@ import (
 func TestUnserialiseGithub(t *testing.T) {
 	validYamls := []string{
 		"flavour: github\nurl: helm/helm\nconstraints: <1.0.0",
+		"flavour: github\nserver: https://github.example.com\ntokenEnv: GHE_TOKEN\nurl: honk/honk\nbranch: main",
 	}
 
 	for _, valid := range validYamls {
@@ -154,3 +158,162 @@ func TestSelectHighestVersionUnorderedWithConstraints(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "v1.29.2", v)
 }
+
+// githubEnterpriseHandler serves a minimal subset of the GitHub Enterprise
+// Server API:
+// - honk/releases has releases, including a draft and a pre-release
+// - honk/tags has no release, only tags
+// - honk/monorepo has releases for several components.
+func githubEnterpriseHandler(rw http.ResponseWriter, req *http.Request) {
+	if req.Header.Get("Authorization") != "Bearer honk-token" {
+		rw.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch req.URL.Path {
+	case "/api/v3/repos/honk/releases", "/api/v3/repos/honk/tags", "/api/v3/repos/honk/monorepo":
+		fmt.Fprint(rw, `{"full_name": "honk/repo", "archived": false}`)
+	case "/api/v3/repos/honk/releases/releases":
+		fmt.Fprint(rw, `[
+			{"tag_name": "v1.3.0", "draft": true},
+			{"tag_name": "v2.0.0-rc.1", "prerelease": true},
+			{"tag_name": "v1.2.1"},
+			{"tag_name": "v1.2.0"}
+		]`)
+	case "/api/v3/repos/honk/tags/releases":
+		fmt.Fprint(rw, `[]`)
+	case "/api/v3/repos/honk/tags/tags":
+		fmt.Fprint(rw, `[{"name": "0.1.0"}, {"name": "0.3.0"}, {"name": "0.2.0"}]`)
+	case "/api/v3/repos/honk/monorepo/releases":
+		fmt.Fprint(rw, `[
+			{"tag_name": "api/v0.18.0", "published_at": "2024-05-01T00:00:00Z", "body": "API notes"},
+			{"tag_name": "kustomize/v5.4.1", "published_at": "2024-04-01T00:00:00Z", "body": "## Fixes\n\n- Fix kustomize"},
+			{"tag_name": "kustomize/v5.3.0", "published_at": "2024-01-01T00:00:00Z", "body": "Kustomize notes"},
+			{"tag_name": "kyaml/v0.17.1", "published_at": "2024-02-01T00:00:00Z"}
+		]`)
+	case "/api/v3/repos/honk/releases/branches":
+		fmt.Fprint(rw, `[{"name": "main", "commit": {"sha": "0123456789abcdef"}}]`)
+	default:
+		rw.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestGithubEnterpriseReleases(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(githubEnterpriseHandler))
+	defer server.Close()
+	t.Setenv("GITHUB_TOKEN", "honk-token")
+
+	gh := Github{
+		Server: server.URL,
+		URL:    "honk/releases",
+	}
+
+	latestVersion, err := gh.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "v1.2.1", latestVersion)
+
+	gh.Constraints = "< 1.2.1"
+	latestVersion, err = gh.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "v1.2.0", latestVersion)
+
+	gh = Github{
+		Server: server.URL + "/",
+		URL:    "honk/tags",
+	}
+	latestVersion, err = gh.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "0.3.0", latestVersion)
+}
+
+func TestGithubEnterpriseBranch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(githubEnterpriseHandler))
+	defer server.Close()
+	t.Setenv("GHE_TOKEN", "honk-token")
+
+	gh := Github{
+		Server:   server.URL,
+		TokenEnv: "GHE_TOKEN",
+		URL:      "honk/releases",
+		Branch:   "main",
+	}
+
+	latestVersion, err := gh.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "0123456789abcdef", latestVersion)
+
+	gh.Branch = "branch_that_does_not_exist"
+	_, err = gh.LatestVersion()
+	require.Error(t, err)
+}
+
+func TestGithubEnterpriseUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(githubEnterpriseHandler))
+	defer server.Close()
+	t.Setenv("GITHUB_TOKEN", "honk-token")
+	t.Setenv("GHE_TOKEN", "")
+
+	gh := Github{
+		Server:   server.URL,
+		TokenEnv: "GHE_TOKEN",
+		URL:      "honk/releases",
+	}
+
+	_, err := gh.LatestVersion()
+	require.Error(t, err)
+}
+
+func TestGithubMonorepoTags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(githubEnterpriseHandler))
+	defer server.Close()
+	t.Setenv("GITHUB_TOKEN", "honk-token")
+
+	gh := Github{
+		Server:    server.URL,
+		URL:       "honk/monorepo",
+		TagPrefix: "kustomize/",
+	}
+
+	latestVersion, err := gh.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "v5.4.1", latestVersion)
+
+	gh = Github{
+		Server:     server.URL,
+		URL:        "honk/monorepo",
+		TagPattern: `^(?:api|kyaml)/(?P<version>v[\d.]+)$`,
+	}
+	latestVersion, err = gh.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "v0.18.0", latestVersion)
+
+	gh.TagPrefix = "kustomize/"
+	_, err = gh.LatestVersion()
+	require.Error(t, err)
+}
+
+func TestGithubPrereleases(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(githubEnterpriseHandler))
+	defer server.Close()
+	t.Setenv("GITHUB_TOKEN", "honk-token")
+
+	gh := Github{
+		Base:   Base{CurrentVersion: "v1.2.0", Prereleases: PrereleasesInclude},
+		Server: server.URL,
+		URL:    "honk/releases",
+	}
+
+	latestVersion, err := gh.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "v2.0.0-rc.1", latestVersion)
+
+	gh.Base = Base{CurrentVersion: "v1.3.0-rc.1"}
+	latestVersion, err = gh.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "v2.0.0-rc.1", latestVersion)
+
+	gh.Base = Base{CurrentVersion: "v1.3.0-rc.1", Prereleases: PrereleasesExclude}
+	latestVersion, err = gh.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "v1.2.1", latestVersion)
+}
@@ -44,6 +44,15 @@ type GitLab struct {
 	// If branch is specified, the version should be a commit SHA
 	// Will look for new commits on the branch
 	Branch string
+
+	// Optional: only consider tags starting with this prefix, which is stripped
+	// from the version, e.g. kustomize/ for monorepos tagging kustomize/v5.4.1
+	TagPrefix string
+
+	// Optional: only consider tags matching this regular expression, with the
+	// version captured by the `version` named group or the first group,
+	// e.g. ^kustomize/(v.*)$
+	TagPattern string
 }
 
 // LatestVersion returns the latest non-draft, non-prerelease GitLab Release
@@ -97,7 +106,7 @@ func latestGitLabRelease(upstream *GitLab) (string, error) {
 	owner := splitURL[0]
 	repo := strings.Join(splitURL[1:], "/")
 
-	var tags []string
+	var releaseTags []Release
 	// We'll need to fetch all releases, as GitLab doesn't provide sorting options.
 	// If we don't do that, we risk running into the case where for example:
 	// - Version 1.0.0 and 2.0.0 exist
@@ -117,7 +126,11 @@ func latestGitLabRelease(upstream *GitLab) (string, error) {
 		}
 
 		for _, tag := range gitLabTags {
-			tags = append(tags, tag.Name)
+			releaseTag := Release{Version: tag.Name}
+			if tag.Commit != nil && tag.Commit.CommittedDate != nil {
+				releaseTag.Published = *tag.Commit.CommittedDate
+			}
+			releaseTags = append(releaseTags, releaseTag)
 		}
 	} else {
 		for _, release := range releases {
@@ -125,11 +138,25 @@ func latestGitLabRelease(upstream *GitLab) (string, error) {
 				log.Debug("Skipping release without TagName")
 			}
 
-			tags = append(tags, release.TagName)
+			releaseTag := Release{Version: release.TagName, Notes: release.Description}
+			if release.ReleasedAt != nil {
+				releaseTag.Published = *release.ReleasedAt
+			}
+			releaseTags = append(releaseTags, releaseTag)
 		}
 	}
 
-	return selectHighestVersion(upstream.Constraints, expectedRange, tags)
+	releaseTags, err = componentReleases(upstream.TagPrefix, upstream.TagPattern, releaseTags)
+	if err != nil {
+		return "", fmt.Errorf("invalid gitlab upstream: %w", err)
+	}
+
+	versions, err := upstream.filterReleases(releaseTags)
+	if err != nil {
+		return "", fmt.Errorf("invalid gitlab upstream: %w", err)
+	}
+
+	return selectHighestVersion(upstream.Constraints, expectedRange, versions)
 }
 
 func latestGitlabCommit(upstream *GitLab) (string, error) {
@@ -17,6 +17,9 @@ limitations under the License.
 package upstream
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -27,6 +30,7 @@ func TestUnserialiseGitLab(t *testing.T) {
 	validYamls := []string{
 		"flavour: gitlab\nurl: helm/helm\nconstraints: <1.0.0",
 		"flavour: gitlab\nurl: helm/helm\nserver: https://mygitlab.com/\nconstraints: <1.0.0",
+		"flavour: gitlab\nurl: honk/monorepo\ntagPrefix: kustomize/",
 	}
 
 	for _, valid := range validYamls {
@@ -36,3 +40,63 @@ func TestUnserialiseGitLab(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+// gitlabHandler serves a minimal subset of the GitLab API, with a monorepo
+// project tagging several components and no releases, and a components
+// project releasing several components.
+func gitlabHandler(rw http.ResponseWriter, req *http.Request) {
+	if req.Header.Get("Private-Token") != "honk-token" {
+		rw.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch req.URL.Path {
+	case "/api/v4/projects/honk/monorepo/releases":
+		fmt.Fprint(rw, `[]`)
+	case "/api/v4/projects/honk/monorepo/repository/tags":
+		fmt.Fprint(rw, `[
+			{"name": "api/v0.18.0", "commit": {"committed_date": "2024-05-01T00:00:00Z"}},
+			{"name": "kustomize/v5.4.1", "commit": {"committed_date": "2024-04-01T00:00:00Z"}},
+			{"name": "kustomize/v5.3.0", "commit": {"committed_date": "2024-01-01T00:00:00Z"}},
+			{"name": "v6.0.0", "commit": {"committed_date": "2024-06-01T00:00:00Z"}}
+		]`)
+	case "/api/v4/projects/honk/components/releases":
+		fmt.Fprint(rw, `[
+			{"tag_name": "api/v0.18.0", "description": "API notes", "released_at": "2024-05-01T00:00:00Z"},
+			{"tag_name": "kustomize/v5.4.1", "description": "## Fixes\n\n- Fix kustomize", "released_at": "2024-04-01T00:00:00Z"},
+			{"tag_name": "kustomize/v5.3.0", "description": "Kustomize notes", "released_at": "2024-01-01T00:00:00Z"}
+		]`)
+	default:
+		rw.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestGitLabMonorepoTags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(gitlabHandler))
+	defer server.Close()
+	t.Setenv("GITLAB_PRIVATE_TOKEN", "honk-token")
+
+	gl := GitLab{
+		Server:    server.URL + "/",
+		URL:       "honk/monorepo",
+		TagPrefix: "kustomize/",
+	}
+
+	latestVersion, err := gl.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "v5.4.1", latestVersion)
+
+	gl.Constraints = "< 5.4.0"
+	latestVersion, err = gl.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "v5.3.0", latestVersion)
+
+	gl = GitLab{
+		Server:     server.URL + "/",
+		URL:        "honk/monorepo",
+		TagPattern: `^api/v(.+)$`,
+	}
+	latestVersion, err = gl.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "0.18.0", latestVersion)
+}
@@ -17,18 +17,24 @@ limitations under the License.
 package upstream
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/blang/semver/v4"
 	log "github.com/sirupsen/logrus"
+	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/cli"
 	"helm.sh/helm/v3/pkg/getter"
 	"helm.sh/helm/v3/pkg/repo"
+
+	"sigs.k8s.io/zeitgeist/pkg/container"
 )
 
 // Helm upstream representation.
@@ -36,6 +42,7 @@ type Helm struct {
 	Base `mapstructure:",squash"`
 
 	// Helm repository URL, e.g. https://grafana.github.io/helm-charts
+	// or oci://ghcr.io/prometheus-community/charts for OCI registries
 	Repo string
 
 	// Helm chart name in this repository
@@ -44,10 +51,41 @@ type Helm struct {
 	// Optional: semver constraints, e.g. < 2.0.0
 	// Will have no effect if the dependency does not follow Semver
 	Constraints string
+
+	// Optional: environment variables holding the repository username and
+	// password, which must be set together
+	// For OCI registries, they default to REGISTRY_USERNAME and
+	// REGISTRY_USER_PASSWORD, then to the Docker config file
+	UsernameEnv string
+	PasswordEnv string
+
+	// Optional: TLS configuration for HTTPS repositories, not supported for
+	// OCI registries
+	// CAFile verifies the server certificate, CertFile and KeyFile identify
+	// the client
+	CAFile                string
+	CertFile              string
+	KeyFile               string
+	InsecureSkipTLSVerify bool
+
+	// Optional: what to track from the selected chart, either "version"
+	// (default) or "appVersion"
+	// Tracking the appVersion allows to check e.g. an image tag against the
+	// chart version selected by the Constraints
+	Track string
 }
 
+const (
+	// HelmTrackVersion tracks the chart version.
+	HelmTrackVersion = "version"
+
+	// HelmTrackAppVersion tracks the appVersion of the chart.
+	HelmTrackAppVersion = "appVersion"
+)
+
 // LatestVersion returns the latest non-draft, non-prerelease Helm Release
-// for the given repository (depending on the Constraints if set).
+// for the given repository (depending on the Constraints if set), or its
+// appVersion if Track is set to appVersion.
 func (upstream Helm) LatestVersion() (string, error) {
 	log.Debug("Using Helm flavour")
 	return latestChartVersion(upstream)
@@ -73,6 +111,27 @@ func latestChartVersion(upstream Helm) (string, error) {
 		return "", fmt.Errorf("invalid helm repo: %s, only http, https and oci are supported", upstream.Repo)
 	}
 
+	if upstream.Track != "" && upstream.Track != HelmTrackVersion && upstream.Track != HelmTrackAppVersion {
+		return "", fmt.Errorf(
+			"invalid helm upstream: track must be one of %s or %s, got %q",
+			HelmTrackVersion, HelmTrackAppVersion, upstream.Track,
+		)
+	}
+
+	if (upstream.CertFile == "") != (upstream.KeyFile == "") {
+		return "", errors.New("invalid helm upstream: certFile and keyFile must be set together")
+	}
+
+	if (upstream.UsernameEnv == "") != (upstream.PasswordEnv == "") {
+		return "", errors.New("invalid helm upstream: usernameEnv and passwordEnv must be set together")
+	}
+
+	if s == "oci" && (upstream.CAFile != "" || upstream.CertFile != "" || upstream.InsecureSkipTLSVerify) {
+		return "", errors.New(
+			"invalid helm upstream: caFile, certFile, keyFile and insecureSkipTLSVerify are not supported for OCI registries",
+		)
+	}
+
 	var useSemverConstraints bool
 	var expectedRange semver.Range
 	semverConstraints := upstream.Constraints
@@ -87,18 +146,154 @@ func latestChartVersion(upstream Helm) (string, error) {
 		expectedRange = validatedExpectedRange
 	}
 
+	includePrereleases, err := upstream.includePrereleases(isPrerelease(upstream.CurrentVersion))
+	if err != nil {
+		return "", fmt.Errorf("invalid helm upstream: %w", err)
+	}
+
+	var (
+		chartVersions repo.ChartVersions
+		client        container.Client
+	)
+	if s == "oci" {
+		client, err = newRegistryClient(upstream.Repo, upstream.UsernameEnv, upstream.PasswordEnv)
+		if err != nil {
+			return "", fmt.Errorf("invalid helm upstream: %w", err)
+		}
+		chartVersions, err = ociChartVersions(&upstream, client)
+	} else {
+		chartVersions, err = indexChartVersions(&upstream)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	upstream.recordChartVersions(chartVersions, includePrereleases)
+
+	// Iterate over versions and get the first newer version
+	// (Or the first version that matches our semver constraints, if defined)
+	// Versions are already ordered, cf https://github.com/helm/helm/blob/6a3daaa7aa5b89a150042cadcbe869b477bb62a1/pkg/repo/index.go#L344
+	// When tracking the appVersion, ignored versions and pre-releases are
+	// those of the appVersion, while constraints still apply to the chart
+	tracksAppVersion := upstream.Track == HelmTrackAppVersion
+	for _, chartVersion := range chartVersions {
+		chartVersionStr := strings.TrimPrefix(chartVersion.Version, "v")
+
+		if !tracksAppVersion && upstream.Ignores(chartVersionStr) {
+			log.Debugf("Skipping ignored version: %s\n", chartVersionStr)
+			continue
+		}
+
+		if upstream.MinAge > 0 {
+			if client != nil && chartVersion.Created.IsZero() {
+				chartVersion.Created = ociChartCreated(&upstream, chartVersion, client)
+			}
+			if chartVersion.Created.IsZero() {
+				log.Warnf("The publication time of chart %s %s is unknown, minAge is not applied to it", upstream.Chart, chartVersionStr)
+			}
+		}
+
+		if upstream.tooRecent(chartVersion.Created) {
+			log.Debugf("Skipping version published less than %s ago: %s (%s)\n", upstream.MinAge, chartVersionStr, chartVersion.Created)
+			continue
+		}
+
+		prerelease, err := strconv.ParseBool(chartVersion.Annotations["artifacthub.io/prerelease"])
+		if !tracksAppVersion && err == nil && prerelease && !includePrereleases {
+			log.Debugf("Skipping annotated prerelease: %s\n", chartVersionStr)
+			continue
+		}
+
+		version, err := semver.Parse(chartVersionStr)
+		if err != nil { //nolint:gocritic
+			log.Debugf("Error parsing version %s (%#v) as semver, cannot validate semver constraints", chartVersionStr, err)
+		} else if !tracksAppVersion && len(version.Pre) > 0 && !includePrereleases {
+			log.Debugf("Skipping semver prerelease: %s\n", chartVersionStr)
+			continue
+		} else if useSemverConstraints && !expectedRange(version) {
+			log.Debugf("Skipping release not matching range constraints (%s): %s\n", upstream.Constraints, chartVersionStr)
+			continue
+		}
+
+		if !tracksAppVersion {
+			log.Debugf("Found latest matching release: %s\n", chartVersionStr)
+			return chartVersionStr, nil
+		}
+
+		appVersion, err := chartAppVersion(&upstream, chartVersion, client)
+		if err != nil {
+			return "", err
+		}
+
+		if upstream.Ignores(appVersion) {
+			log.Debugf("Skipping ignored appVersion: %s (chart %s)\n", appVersion, chartVersionStr)
+			continue
+		}
+
+		if isPrerelease(appVersion) && !includePrereleases {
+			log.Debugf("Skipping prerelease appVersion: %s (chart %s)\n", appVersion, chartVersionStr)
+			continue
+		}
+
+		log.Debugf("Found latest matching release: %s (appVersion %s)\n", chartVersionStr, appVersion)
+		return appVersion, nil
+	}
+
+	// No latest version found – no versions? Only prereleases?
+	return "", errors.New("no potential version found")
+}
+
+// recordChartVersions records the candidate chart versions in the History,
+// and their changes in the ReleaseNotes, unless the app version is tracked.
+func (upstream *Helm) recordChartVersions(chartVersions repo.ChartVersions, includePrereleases bool) {
+	if (upstream.History == nil && upstream.ReleaseNotes == nil) || upstream.Track == HelmTrackAppVersion {
+		return
+	}
+
+	for _, chartVersion := range chartVersions {
+		version := strings.TrimPrefix(chartVersion.Version, "v")
+		prerelease, err := strconv.ParseBool(chartVersion.Annotations["artifacthub.io/prerelease"])
+		if upstream.Ignores(version) || upstream.tooRecent(chartVersion.Created) ||
+			(!includePrereleases && ((err == nil && prerelease) || isPrerelease(version))) {
+			continue
+		}
+
+		upstream.History.recordVersions(version)
+		upstream.History.recordPublished(Release{Version: version, Published: chartVersion.Created})
+		upstream.ReleaseNotes.record(version, chartChanges(chartVersion.Annotations["artifacthub.io/changes"]))
+	}
+}
+
+// indexChartVersions returns the versions of the chart from the index of an
+// HTTP Helm repository.
+func indexChartVersions(upstream *Helm) (repo.ChartVersions, error) {
 	// First, get the repo index
 	// Helm expects a cache directory, so we create a temporary one
 	cacheDir, err := os.MkdirTemp("", "zeitgeist-helm-cache")
 	if err != nil {
 		log.Errorf("failed to create temporary directory for Helm cache")
-		return "", err
+		return nil, err
 	}
 	defer os.RemoveAll(cacheDir)
 
 	cfg := repo.Entry{
-		Name: "zeitgeist",
-		URL:  upstream.Repo,
+		Name:                  "zeitgeist",
+		URL:                   upstream.Repo,
+		CAFile:                upstream.CAFile,
+		CertFile:              upstream.CertFile,
+		KeyFile:               upstream.KeyFile,
+		InsecureSkipTLSverify: upstream.InsecureSkipTLSVerify,
+	}
+
+	if upstream.UsernameEnv != "" {
+		cfg.Username = os.Getenv(upstream.UsernameEnv)
+		cfg.Password = os.Getenv(upstream.PasswordEnv)
+		if cfg.Username == "" || cfg.Password == "" {
+			log.Warnf(
+				"No credentials found in %s and %s for Helm repo %s",
+				upstream.UsernameEnv, upstream.PasswordEnv, upstream.Repo,
+			)
+		}
 	}
 	settings := cli.EnvSettings{
 		PluginsDirectory: "",
@@ -107,56 +302,115 @@ func latestChartVersion(upstream Helm) (string, error) {
 	re, err := repo.NewChartRepository(&cfg, getter.All(&settings))
 	if err != nil {
 		log.Errorf("failed to instantiate the Helm Chart Repository")
-		return "", err
+		return nil, err
 	}
 
 	log.Debugf("Downloading repo index for %s...", upstream.Repo)
 	indexFile, err := re.DownloadIndexFile()
 	if err != nil {
 		log.Errorf("failed to download index file for repo %s", upstream.Repo)
-		return "", err
+		return nil, err
 	}
 
 	log.Debugf("Loading repo index for %s...", upstream.Repo)
 	index, err := repo.LoadIndexFile(indexFile)
 	if err != nil {
 		log.Errorf("failed to load index file for repo %s", upstream.Repo)
-		return "", err
+		return nil, err
 	}
 
 	chartVersions := index.Entries[upstream.Chart]
 	if chartVersions == nil {
-		return "", fmt.Errorf("no chart for %s found in repository %s", upstream.Chart, upstream.Repo)
+		return nil, fmt.Errorf("no chart for %s found in repository %s", upstream.Chart, upstream.Repo)
 	}
 
-	// Iterate over versions and get the first newer version
-	// (Or the first version that matches our semver constraints, if defined)
-	// Versions are already ordered, cf https://github.com/helm/helm/blob/6a3daaa7aa5b89a150042cadcbe869b477bb62a1/pkg/repo/index.go#L344
-	for _, chartVersion := range chartVersions {
-		chartVersionStr := strings.TrimPrefix(chartVersion.Version, "v")
+	return chartVersions, nil
+}
 
-		prerelease, err := strconv.ParseBool(chartVersion.Annotations["artifacthub.io/prerelease"])
-		if err == nil && prerelease {
-			log.Debugf("Skipping annotated prerelease: %s\n", chartVersionStr)
+// ociChartRegistry returns the registry holding the chart, e.g.
+// ghcr.io/prometheus-community/charts/kube-prometheus-stack.
+func ociChartRegistry(upstream *Helm) string {
+	return strings.TrimSuffix(strings.TrimPrefix(upstream.Repo, "oci://"), "/") + "/" + upstream.Chart
+}
+
+// ociChartRef returns the reference of a chart version in an OCI registry.
+func ociChartRef(upstream *Helm, chartVersion *repo.ChartVersion) string {
+	// OCI tags cannot contain "+", so Helm replaces it with "_"
+	return ociChartRegistry(upstream) + ":" + strings.ReplaceAll(chartVersion.Version, "+", "_")
+}
+
+// ociChartVersions returns the versions of the chart from the tags of an OCI
+// registry, sorted from newest to oldest as in a repository index.
+func ociChartVersions(upstream *Helm, client container.Client) (repo.ChartVersions, error) {
+	registry := ociChartRegistry(upstream)
+
+	log.Debugf("Retrieving tags for %s...", registry)
+	tags, err := client.ListTags(registry)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Helm chart tags for %s: %w", registry, err)
+	}
+
+	chartVersions := make(repo.ChartVersions, 0, len(tags))
+	for _, tag := range tags {
+		// OCI tags cannot contain "+", so Helm replaces it with "_"
+		version := strings.ReplaceAll(tag, "_", "+")
+		if _, err := semver.ParseTolerant(version); err != nil {
+			log.Debugf("Skipping tag %s which is not a chart version: %v", tag, err)
 			continue
 		}
 
-		version, err := semver.Parse(chartVersionStr)
-		if err != nil { //nolint:gocritic
-			log.Debugf("Error parsing version %s (%#v) as semver, cannot validate semver constraints", chartVersionStr, err)
-		} else if len(version.Pre) > 0 {
-			log.Debugf("Skipping semver prerelease: %s\n", chartVersionStr)
-			continue
-		} else if useSemverConstraints && !expectedRange(version) {
-			log.Debugf("Skipping release not matching range constraints (%s): %s\n", upstream.Constraints, chartVersionStr)
-			continue
+		chartVersions = append(chartVersions, &repo.ChartVersion{
+			Metadata: &chart.Metadata{Name: upstream.Chart, Version: version},
+		})
+	}
+
+	if len(chartVersions) == 0 {
+		return nil, fmt.Errorf("no chart for %s found in repository %s", upstream.Chart, upstream.Repo)
+	}
+
+	sort.Sort(sort.Reverse(chartVersions))
+
+	return chartVersions, nil
+}
+
+// ociChartCreated returns the creation time of a chart version in an OCI
+// registry from the `created` field of its configuration, as for container
+// images, or the zero time if it is unknown.
+func ociChartCreated(upstream *Helm, chartVersion *repo.ChartVersion, client container.Client) time.Time {
+	ref := ociChartRef(upstream, chartVersion)
+	created, err := imageCreated(client, ref)
+	if err != nil {
+		log.Debugf("Cannot retrieve the creation time of chart %s: %v", ref, err)
+		return time.Time{}
+	}
+	return created
+}
+
+// chartAppVersion returns the appVersion of the chart version. Repository
+// indexes include it, while charts in OCI registries have it in their
+// configuration, so client must be set for those.
+func chartAppVersion(upstream *Helm, chartVersion *repo.ChartVersion, client container.Client) (string, error) {
+	if client != nil {
+		ref := ociChartRef(upstream, chartVersion)
+
+		log.Debugf("Retrieving chart metadata for %s...", ref)
+		config, err := client.Config(ref)
+		if err != nil {
+			return "", fmt.Errorf("retrieving Helm chart metadata for %s: %w", ref, err)
 		}
 
-		log.Debugf("Found latest matching release: %s\n", chartVersionStr)
+		var metadata chart.Metadata
+		if err := json.Unmarshal(config, &metadata); err != nil {
+			return "", fmt.Errorf("parsing Helm chart metadata for %s: %w", ref, err)
+		}
+		chartVersion.AppVersion = metadata.AppVersion
+	}
 
-		return chartVersionStr, nil
+	if chartVersion.AppVersion == "" {
+		return "", fmt.Errorf("chart %s %s has no appVersion", upstream.Chart, chartVersion.Version)
 	}
 
-	// No latest version found – no versions? Only prereleases?
-	return "", errors.New("no potential version found")
+	log.Debugf("Chart %s %s has appVersion %s", upstream.Chart, chartVersion.Version, chartVersion.AppVersion)
+
+	return chartVersion.AppVersion, nil
 }
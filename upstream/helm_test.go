@@ -17,14 +17,29 @@ limitations under the License.
 package upstream
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/repo"
+
+	"sigs.k8s.io/zeitgeist/pkg/container/containerfakes"
 )
 
 func TestUnserialiseHelm(t *testing.T) {
@@ -32,6 +47,9 @@ func TestUnserialiseHelm(t *testing.T) {
 		"flavour: helm\nrepo: http://example.com/repo\nchart: example",
 		"flavour: helm\nrepo: https://example.com/repo\nchart: example",
 		"flavour: helm\nrepo: https://example.com/repo\nchart: example\nconstraints: < 1.0.0",
+		"flavour: helm\nrepo: oci://ghcr.io/example/charts\nchart: example",
+		"flavour: helm\nrepo: https://example.com/repo\nchart: example\ntrack: appVersion",
+		"flavour: helm\nrepo: https://example.com/repo\nchart: example\nusernameEnv: HELM_USER\npasswordEnv: HELM_PASSWORD\ncaFile: ca.pem",
 	}
 
 	for _, valid := range validYamls {
@@ -89,6 +107,45 @@ func TestInvalidHelmValues(t *testing.T) {
 
 	_, err = h4.LatestVersion()
 	require.Error(t, err)
+
+	h5 := Helm{
+		// Missing keyFile
+		Repo:     "https://example.com/test",
+		Chart:    "example",
+		CertFile: "cert.pem",
+	}
+
+	_, err = h5.LatestVersion()
+	require.Error(t, err)
+
+	h6 := Helm{
+		Repo:  "https://example.com/test",
+		Chart: "example",
+		Track: "kubeVersion",
+	}
+
+	_, err = h6.LatestVersion()
+	require.Error(t, err)
+
+	h7 := Helm{
+		// Missing passwordEnv
+		Repo:        "https://example.com/test",
+		Chart:       "example",
+		UsernameEnv: "HELM_USER",
+	}
+
+	_, err = h7.LatestVersion()
+	require.ErrorContains(t, err, "usernameEnv and passwordEnv must be set together")
+
+	h8 := Helm{
+		// TLS options are not supported for OCI registries
+		Repo:   "oci://example.com/test",
+		Chart:  "example",
+		CAFile: "ca.pem",
+	}
+
+	_, err = h8.LatestVersion()
+	require.ErrorContains(t, err, "not supported for OCI registries")
 }
 
 // Now onto tests that connect to a "real" Helm repo!
@@ -228,3 +285,304 @@ func TestHelmHappyPathWithPrelease(t *testing.T) {
 	require.NotEmpty(t, latestVersion)
 	require.Equal(t, "0.1.0", latestVersion)
 }
+
+func TestHelmPrereleasePolicy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(helmHandler))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		base     Base
+		expected string
+	}{
+		{base: Base{}, expected: "1.0.0"},
+		{base: Base{CurrentVersion: "1.0.0"}, expected: "1.0.0"},
+		{base: Base{CurrentVersion: "1.1.0-beta.0"}, expected: "1.2.0-rc.1"},
+		{base: Base{CurrentVersion: "1.1.0-beta.0", Prereleases: PrereleasesExclude}, expected: "1.0.0"},
+		{base: Base{CurrentVersion: "1.0.0", Prereleases: PrereleasesInclude}, expected: "1.2.0-rc.1"},
+	} {
+		h := Helm{
+			Base:  tc.base,
+			Repo:  server.URL,
+			Chart: "dependency-four",
+		}
+
+		latestVersion, err := h.LatestVersion()
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, latestVersion)
+	}
+}
+
+func TestHelmOCI(t *testing.T) {
+	repo := newTestRegistry(t, "0.1.0", "0.2.0", "0.3.0-rc.1", "1.0.0_build.1", "latest", "sha256-0123.sig")
+
+	h := Helm{
+		Repo:  "oci://" + strings.TrimSuffix(repo, "/honk"),
+		Chart: "honk",
+	}
+
+	latestVersion, err := h.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "1.0.0+build.1", latestVersion)
+
+	h.Constraints = "< 1.0.0"
+	latestVersion, err = h.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "0.2.0", latestVersion)
+
+	h.Chart = "chart-doesnt-exist"
+	_, err = h.LatestVersion()
+	require.Error(t, err)
+}
+
+func TestHelmOCIMinAge(t *testing.T) {
+	repo := newTestRegistry(t)
+	pushRandomImageCreatedAt(t, repo+":0.1.0", time.Now().Add(-30*24*time.Hour))
+	pushRandomImageCreatedAt(t, repo+":0.2.0", time.Now().Add(-time.Hour))
+
+	h := Helm{
+		Base:  Base{MinAge: 7 * 24 * time.Hour},
+		Repo:  "oci://" + strings.TrimSuffix(repo, "/honk"),
+		Chart: "honk",
+	}
+
+	latestVersion, err := h.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "0.1.0", latestVersion)
+
+	h.MinAge = 0
+	latestVersion, err = h.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "0.2.0", latestVersion)
+}
+
+// writePEM writes a PEM block to a new file in dir, and returns its path.
+func writePEM(t *testing.T, dir, name, blockType string, data []byte) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: data}), 0o600))
+
+	return path
+}
+
+// newAuthenticatedHelmServer starts a TLS Helm repository only accepting
+// honk:goose as basic auth credentials, and returns it along with the path to
+// its CA certificate.
+func newAuthenticatedHelmServer(t *testing.T) (*httptest.Server, string) {
+	t.Helper()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		user, pass, ok := req.BasicAuth()
+		if !ok || user != "honk" || pass != "goose" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		helmHandler(rw, req)
+	}))
+	t.Cleanup(server.Close)
+
+	caFile := writePEM(t, t.TempDir(), "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+
+	return server, caFile
+}
+
+func TestHelmAuthenticatedRepo(t *testing.T) {
+	server, caFile := newAuthenticatedHelmServer(t)
+	t.Setenv("HELM_USER", "honk")
+	t.Setenv("HELM_PASSWORD", "goose")
+
+	h := Helm{
+		Repo:        server.URL,
+		Chart:       "dependency",
+		UsernameEnv: "HELM_USER",
+		PasswordEnv: "HELM_PASSWORD",
+	}
+
+	// Unknown certificate authority
+	_, err := h.LatestVersion()
+	require.Error(t, err)
+
+	h.CAFile = caFile
+	latestVersion, err := h.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "0.2.0", latestVersion)
+
+	h.CAFile = ""
+	h.InsecureSkipTLSVerify = true
+	latestVersion, err = h.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "0.2.0", latestVersion)
+
+	t.Setenv("HELM_PASSWORD", "duck")
+	_, err = h.LatestVersion()
+	require.Error(t, err)
+}
+
+func TestHelmClientCertificate(t *testing.T) {
+	dir := t.TempDir()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "zeitgeist"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	parsedCert, err := x509.ParseCertificate(cert)
+	require.NoError(t, err)
+
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := writePEM(t, dir, "client.pem", "CERTIFICATE", cert)
+	keyFile := writePEM(t, dir, "client-key.pem", "EC PRIVATE KEY", keyBytes)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(parsedCert)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(helmHandler))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	h := Helm{
+		Repo:                  server.URL,
+		Chart:                 "dependency",
+		InsecureSkipTLSVerify: true,
+	}
+
+	// Missing client certificate
+	_, err = h.LatestVersion()
+	require.Error(t, err)
+
+	h.CertFile = certFile
+	h.KeyFile = keyFile
+	latestVersion, err := h.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "0.2.0", latestVersion)
+}
+
+func TestHelmTrackAppVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(helmHandler))
+	defer server.Close()
+
+	h := Helm{
+		Repo:  server.URL,
+		Chart: "dependency",
+		Track: "appVersion",
+	}
+
+	latestVersion, err := h.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "1.2.0", latestVersion)
+
+	h.Constraints = "< 0.2.0"
+	latestVersion, err = h.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "1.1.2", latestVersion)
+}
+
+func TestHelmTrackAppVersionFilters(t *testing.T) {
+	recently := time.Now().UTC().Format(time.RFC3339)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/index.yaml" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(rw, `apiVersion: v2
+entries:
+  app:
+  - {name: app, version: 0.4.0, appVersion: 2.0.0-rc.1, created: "2021-01-01T00:00:00Z"}
+  - {name: app, version: 0.3.0, appVersion: 1.3.0, created: "%s"}
+  - {name: app, version: 0.2.1, appVersion: 1.2.1, created: "2021-01-01T00:00:00Z"}
+  - {name: app, version: 0.2.0, appVersion: 1.2.0, created: "2021-01-01T00:00:00Z"}
+`, recently)
+	}))
+	defer server.Close()
+
+	h := Helm{
+		Base:  Base{CurrentVersion: "1.2.0"},
+		Repo:  server.URL,
+		Chart: "app",
+		Track: "appVersion",
+	}
+
+	// The pre-release is that of the appVersion, the chart version is stable
+	latestVersion, err := h.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "1.3.0", latestVersion)
+
+	h.Prereleases = PrereleasesInclude
+	latestVersion, err = h.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "2.0.0-rc.1", latestVersion)
+	h.Prereleases = ""
+
+	// Ignored versions are appVersions, not chart versions
+	h.IgnoreVersions = []string{"0.3.0"}
+	latestVersion, err = h.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "1.3.0", latestVersion)
+
+	h.IgnoreVersions = []string{"1.3.0"}
+	latestVersion, err = h.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "1.2.1", latestVersion)
+
+	// The appVersion is published with its chart
+	h.IgnoreVersions = nil
+	h.MinAge = 7 * 24 * time.Hour
+	latestVersion, err = h.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "1.2.1", latestVersion)
+}
+
+func TestHelmOCIAppVersion(t *testing.T) {
+	h := Helm{
+		Repo:  "oci://ghcr.io/honk/charts/",
+		Chart: "honk",
+		Track: "appVersion",
+	}
+	chartVersion := &repo.ChartVersion{Metadata: &chart.Metadata{Name: "honk", Version: "1.0.0+build.1"}}
+
+	client := &containerfakes.FakeClient{}
+	client.ConfigReturns([]byte(`{"name": "honk", "version": "1.0.0+build.1", "appVersion": "v2.3.1"}`), nil)
+
+	appVersion, err := chartAppVersion(&h, chartVersion, client)
+	require.NoError(t, err)
+	require.Equal(t, "v2.3.1", appVersion)
+	require.Equal(t, "ghcr.io/honk/charts/honk:1.0.0_build.1", client.ConfigArgsForCall(0))
+
+	client.ConfigReturns([]byte(`{"name": "honk", "version": "1.0.0+build.1"}`), nil)
+	_, err = chartAppVersion(&h, chartVersion, client)
+	require.Error(t, err)
+}
+
+func TestHelmMinAge(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(helmHandler))
+	defer server.Close()
+
+	// All chart versions of the test repository were created on 2021-01-01
+	h := Helm{
+		Base:  Base{MinAge: time.Since(time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)) + 24*time.Hour},
+		Repo:  server.URL,
+		Chart: "dependency",
+	}
+
+	_, err := h.LatestVersion()
+	require.Error(t, err)
+
+	h.MinAge = 7 * 24 * time.Hour
+	latestVersion, err := h.LatestVersion()
+	require.NoError(t, err)
+	require.NotEmpty(t, latestVersion)
+}
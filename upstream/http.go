@@ -0,0 +1,230 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstream
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/blang/semver/v4"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// HTTP is a generic upstream, scraping versions from a web page or a JSON endpoint.
+//
+// Exactly one of Regex, JSONPath or Selector must be set, to define how
+// versions are extracted from the response body.
+type HTTP struct {
+	Base `mapstructure:",squash"`
+
+	// URL to retrieve versions from, e.g. https://example.com/releases.json
+	URL string
+
+	// Regex matching versions in the body, e.g. `<code>(?P<version>\d+\.\d+\.\d+)</code>`
+	// The `version` named group is used if present, otherwise the whole match
+	Regex string
+
+	// JSONPath expression selecting versions in a JSON body, e.g. {.releases[*].name}
+	JSONPath string
+
+	// CSS selector for the elements holding versions in an HTML body, e.g. table td.version
+	Selector string
+
+	// Optional: request headers, one `Name: value` per line
+	// Environment variables (e.g. ${API_TOKEN}) are expanded in values
+	Headers string
+
+	// Optional: semver constraints, e.g. < 2.0.0
+	// Will have no effect if the dependency does not follow Semver
+	Constraints string
+}
+
+// LatestVersion returns the highest version found in the body of the
+// configured URL (depending on the Constraints if set).
+func (upstream HTTP) LatestVersion() (string, error) { //nolint:gocritic
+	log.Debug("Using HTTP flavour")
+	return latestHTTPVersion(&upstream)
+}
+
+func latestHTTPVersion(upstream *HTTP) (string, error) {
+	if upstream.URL == "" {
+		return "", errors.New("invalid http upstream: missing url argument")
+	}
+
+	extract, err := upstream.extractor()
+	if err != nil {
+		return "", err
+	}
+
+	semverConstraints := upstream.Constraints
+	if semverConstraints == "" {
+		// If no range is passed, just use the broadest possible range
+		semverConstraints = DefaultSemVerConstraints
+	}
+
+	expectedRange, err := semver.ParseRange(semverConstraints)
+	if err != nil {
+		return "", fmt.Errorf("invalid semver constraints range: %v: %w", upstream.Constraints, err)
+	}
+
+	log.Debugf("Retrieving versions from %s...", upstream.URL)
+	body, err := upstream.fetch()
+	if err != nil {
+		return "", err
+	}
+
+	versions, err := extract(body)
+	if err != nil {
+		return "", fmt.Errorf("extracting versions from %s: %w", upstream.URL, err)
+	}
+	log.Debugf("Found %d versions in %s", len(versions), upstream.URL)
+
+	versions, err = upstream.filterVersions(versions)
+	if err != nil {
+		return "", fmt.Errorf("invalid http upstream: %w", err)
+	}
+
+	return selectHighestVersion(upstream.Constraints, expectedRange, versions)
+}
+
+// extractor returns the function used to extract versions from a response body.
+func (upstream *HTTP) extractor() (func([]byte) ([]string, error), error) {
+	var extractors []func([]byte) ([]string, error)
+
+	if upstream.Regex != "" {
+		r, err := regexp.Compile(upstream.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("compiling regex: %w", err)
+		}
+		extractors = append(extractors, func(body []byte) ([]string, error) {
+			return extractRegex(r, body), nil
+		})
+	}
+
+	if upstream.JSONPath != "" {
+		j := jsonpath.New("zeitgeist").AllowMissingKeys(true)
+		if err := j.Parse(upstream.JSONPath); err != nil {
+			return nil, fmt.Errorf("parsing jsonpath: %w", err)
+		}
+		extractors = append(extractors, func(body []byte) ([]string, error) {
+			return extractJSONPath(j, body)
+		})
+	}
+
+	if upstream.Selector != "" {
+		selector := upstream.Selector
+		extractors = append(extractors, func(body []byte) ([]string, error) {
+			return extractSelector(selector, body)
+		})
+	}
+
+	if len(extractors) != 1 {
+		return nil, errors.New("invalid http upstream: exactly one of regex, jsonPath or selector must be set")
+	}
+
+	return extractors[0], nil
+}
+
+func (upstream *HTTP) fetch() ([]byte, error) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, upstream.URL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	for _, header := range strings.Split(upstream.Headers, "\n") {
+		if strings.TrimSpace(header) == "" {
+			continue
+		}
+		name, value, found := strings.Cut(header, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid header %q, expected `Name: value`", header)
+		}
+		req.Header.Set(strings.TrimSpace(name), os.ExpandEnv(strings.TrimSpace(value)))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status retrieving %s: %s", upstream.URL, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+func extractRegex(r *regexp.Regexp, body []byte) []string {
+	group := r.SubexpIndex("version")
+	if group == -1 {
+		group = 0
+	}
+
+	var versions []string
+	for _, match := range r.FindAllSubmatch(body, -1) {
+		versions = append(versions, strings.TrimSpace(string(match[group])))
+	}
+
+	return versions
+}
+
+func extractJSONPath(j *jsonpath.JSONPath, body []byte) ([]string, error) {
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, fmt.Errorf("decoding json: %w", err)
+	}
+
+	results, err := j.FindResults(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, result := range results {
+		for _, value := range result {
+			versions = append(versions, strings.TrimSpace(fmt.Sprint(value.Interface())))
+		}
+	}
+
+	return versions, nil
+}
+
+func extractSelector(selector string, body []byte) ([]string, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("parsing html: %w", err)
+	}
+
+	var versions []string
+	doc.Find(selector).Each(func(_ int, s *goquery.Selection) {
+		versions = append(versions, strings.TrimSpace(s.Text()))
+	})
+
+	return versions, nil
+}
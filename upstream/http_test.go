@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstream
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestUnserialiseHTTP(t *testing.T) {
+	validYamls := []string{
+		"flavour: http\nurl: https://example.com/releases\nregex: v(?P<version>[0-9.]+)",
+		"flavour: http\nurl: https://example.com/releases.json\njsonPath: '{.releases[*].name}'\nconstraints: < 2.0.0",
+		"flavour: http\nurl: https://example.com/releases\nselector: td.version\nheaders: 'Accept: text/html'",
+	}
+
+	for _, valid := range validYamls {
+		var u HTTP
+
+		err := yaml.Unmarshal([]byte(valid), &u)
+		require.NoError(t, err)
+	}
+}
+
+func httpHandler(rw http.ResponseWriter, req *http.Request) {
+	switch req.URL.Path {
+	case "/page.html":
+		fmt.Fprint(rw, `<html><body><table>
+<tr><td class="version">1.2.0</td><td>Old</td></tr>
+<tr><td class="version">1.10.1</td><td>Current</td></tr>
+<tr><td class="version">2.0.0-rc.1</td><td>Next</td></tr>
+</table><p>Latest release: <code>v1.10.1</code></p></body></html>`)
+	case "/releases.json":
+		fmt.Fprint(rw, `{"releases": [{"name": "0.9.0"}, {"name": "1.1.0"}, {"name": "1.0.3"}]}`)
+	case "/private.json":
+		if req.Header.Get("Authorization") != "Bearer honk" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(rw, `["3.0.0", "3.1.0"]`)
+	default:
+		rw.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestInvalidHTTPValues(t *testing.T) {
+	for _, h := range []HTTP{
+		{Regex: "v[0-9.]+"},
+		{URL: "http://example.com"},
+		{URL: "http://example.com", Regex: "v[0-9.]+", Selector: "td"},
+		{URL: "http://example.com", Regex: "(bad"},
+		{URL: "http://example.com", JSONPath: "{.bad"},
+		{URL: "http://example.com", Regex: "v[0-9.]+", Constraints: "bad-constraint"},
+	} {
+		_, err := h.LatestVersion()
+		require.Error(t, err)
+	}
+}
+
+func TestHTTPNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(httpHandler))
+	defer server.Close()
+
+	h := HTTP{
+		URL:   server.URL + "/not-found",
+		Regex: "v[0-9.]+",
+	}
+
+	latestVersion, err := h.LatestVersion()
+	require.Error(t, err)
+	require.Empty(t, latestVersion)
+}
+
+func TestHTTPRegex(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(httpHandler))
+	defer server.Close()
+
+	h := HTTP{
+		URL:   server.URL + "/page.html",
+		Regex: `<td class="version">(?P<version>[^<]+)</td>`,
+	}
+
+	latestVersion, err := h.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "1.10.1", latestVersion)
+
+	h.Prereleases = PrereleasesInclude
+	latestVersion, err = h.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "2.0.0-rc.1", latestVersion)
+
+	h.Constraints = "< 2.0.0-0"
+	latestVersion, err = h.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "1.10.1", latestVersion)
+
+	h.Regex = `v[0-9]+\.[0-9]+\.[0-9]+`
+	latestVersion, err = h.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "v1.10.1", latestVersion)
+}
+
+func TestHTTPJSONPath(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(httpHandler))
+	defer server.Close()
+
+	h := HTTP{
+		URL:      server.URL + "/releases.json",
+		JSONPath: "{.releases[*].name}",
+	}
+
+	latestVersion, err := h.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "1.1.0", latestVersion)
+
+	h.Constraints = "< 1.1.0"
+	latestVersion, err = h.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "1.0.3", latestVersion)
+}
+
+func TestHTTPSelector(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(httpHandler))
+	defer server.Close()
+
+	h := HTTP{
+		URL:         server.URL + "/page.html",
+		Selector:    "table td.version",
+		Constraints: "< 2.0.0-0",
+	}
+
+	latestVersion, err := h.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "1.10.1", latestVersion)
+}
+
+func TestHTTPHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(httpHandler))
+	defer server.Close()
+
+	h := HTTP{
+		URL:      server.URL + "/private.json",
+		JSONPath: "{[*]}",
+	}
+
+	_, err := h.LatestVersion()
+	require.Error(t, err)
+
+	t.Setenv("ZEITGEIST_TEST_TOKEN", "honk")
+	h.Headers = "Accept: application/json\nAuthorization: Bearer ${ZEITGEIST_TEST_TOKEN}"
+
+	latestVersion, err := h.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "3.1.0", latestVersion)
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/blang/semver/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+// SSM is the AWS Systems Manager Parameter Store upstream, e.g. for the
+// public parameters AWS publishes with the recommended AMI IDs.
+//
+// See: https://docs.aws.amazon.com/systems-manager/latest/userguide/parameter-store-public-parameters.html
+type SSM struct {
+	Base `mapstructure:",squash"`
+
+	// Optional: AWS profile, region and role to assume
+	AWSOptions `mapstructure:",squash"`
+
+	// Name of the parameter holding the version, e.g.
+	// /aws/service/eks/optimized-ami/1.30/amazon-linux-2/recommended/image_id
+	Parameter string
+
+	// Path holding one parameter per version, e.g.
+	// /aws/service/bottlerocket/aws-k8s-1.30/x86_64
+	// The version is the highest parameter name directly under the path
+	Path string
+
+	// Optional: semver constraints when using Path, e.g. < 2.0.0
+	Constraints string
+
+	// ServiceClient is the AWS client to talk to AWS API
+	ServiceClient SSMGetParametersAPI
+}
+
+// SSMGetParametersAPI is the subset of the SSM API used by the SSM upstream.
+type SSMGetParametersAPI interface {
+	GetParameter(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error)
+	GetParametersByPath(ctx context.Context, params *ssm.GetParametersByPathInput, optFns ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error)
+}
+
+// NewSSMClient returns a new AWS service client for SSM, configured as
+// NewAWSClient.
+func NewSSMClient(options AWSOptions) (*ssm.Client, error) {
+	cfg, err := LoadAWSConfig(context.Background(), options)
+	if err != nil {
+		return nil, err
+	}
+
+	return ssm.NewFromConfig(cfg), nil
+}
+
+// LatestVersion returns the value of the Parameter, or the highest version
+// under the Path (depending on the Constraints if set).
+func (upstream SSM) LatestVersion() (string, error) {
+	log.Debug("Using SSM upstream")
+
+	if (upstream.Parameter == "") == (upstream.Path == "") {
+		return "", errors.New("invalid ssm upstream: exactly one of parameter or path must be set")
+	}
+
+	if upstream.ServiceClient == nil {
+		return "", errors.New("no AWS client configured for SSM upstream")
+	}
+
+	if upstream.Parameter != "" {
+		return latestSSMParameter(&upstream)
+	}
+	return latestSSMPathVersion(&upstream)
+}
+
+func latestSSMParameter(upstream *SSM) (string, error) {
+	log.Debugf("Retrieving SSM parameter %s...", upstream.Parameter)
+	result, err := upstream.ServiceClient.GetParameter(context.Background(), &ssm.GetParameterInput{
+		Name: aws.String(upstream.Parameter),
+	})
+	if err != nil {
+		return "", fmt.Errorf("retrieving SSM parameter %s: %w", upstream.Parameter, err)
+	}
+
+	if result.Parameter == nil || aws.ToString(result.Parameter.Value) == "" {
+		return "", fmt.Errorf("SSM parameter %s has no value", upstream.Parameter)
+	}
+
+	return aws.ToString(result.Parameter.Value), nil
+}
+
+func latestSSMPathVersion(upstream *SSM) (string, error) {
+	semverConstraints := upstream.Constraints
+	if semverConstraints == "" {
+		// If no range is passed, just use the broadest possible range
+		semverConstraints = DefaultSemVerConstraints
+	}
+
+	expectedRange, err := semver.ParseRange(semverConstraints)
+	if err != nil {
+		return "", fmt.Errorf("invalid semver constraints range: %#v: %w", upstream.Constraints, err)
+	}
+
+	prefix := strings.TrimSuffix(upstream.Path, "/") + "/"
+
+	var releases []Release
+	input := &ssm.GetParametersByPathInput{
+		Path:      aws.String(upstream.Path),
+		Recursive: aws.Bool(true),
+	}
+	paginator := ssm.NewGetParametersByPathPaginator(upstream.ServiceClient, input)
+	for paginator.HasMorePages() {
+		log.Debugf("Retrieving SSM parameters under %s...", upstream.Path)
+		page, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return "", fmt.Errorf("retrieving SSM parameters under %s: %w", upstream.Path, err)
+		}
+
+		for _, parameter := range page.Parameters {
+			// Keep the name directly under the path, e.g. 1.20.0 for
+			// <path>/1.20.0/image_id
+			name, _, _ := strings.Cut(strings.TrimPrefix(aws.ToString(parameter.Name), prefix), "/")
+			release := Release{Version: name}
+			if parameter.LastModifiedDate != nil {
+				release.Published = *parameter.LastModifiedDate
+			}
+			releases = append(releases, release)
+		}
+	}
+
+	versions, err := upstream.filterReleases(releases)
+	if err != nil {
+		return "", fmt.Errorf("invalid ssm upstream: %w", err)
+	}
+
+	return selectHighestVersion(upstream.Constraints, expectedRange, versions)
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstream
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// mockSSMApi serves parameters by name, and parameters by path split over
+// one page per slice.
+type mockSSMApi struct {
+	parameters map[string]string
+	pages      [][]types.Parameter
+}
+
+func (m mockSSMApi) GetParameter(_ context.Context, params *ssm.GetParameterInput, _ ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+	value, ok := m.parameters[aws.ToString(params.Name)]
+	if !ok {
+		return nil, errors.New("ParameterNotFound")
+	}
+
+	return &ssm.GetParameterOutput{
+		Parameter: &types.Parameter{Name: params.Name, Value: aws.String(value)},
+	}, nil
+}
+
+func (m mockSSMApi) GetParametersByPath(_ context.Context, params *ssm.GetParametersByPathInput, _ ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error) {
+	page := 0
+	if params.NextToken != nil {
+		page, _ = strconv.Atoi(*params.NextToken)
+	}
+
+	output := &ssm.GetParametersByPathOutput{Parameters: m.pages[page]}
+	if page+1 < len(m.pages) {
+		output.NextToken = aws.String(strconv.Itoa(page + 1))
+	}
+
+	return output, nil
+}
+
+func TestUnserialiseSSM(t *testing.T) {
+	validYamls := []string{
+		"flavour: ssm\nparameter: /aws/service/eks/optimized-ami/1.30/amazon-linux-2/recommended/image_id",
+		"flavour: ssm\npath: /aws/service/bottlerocket/aws-k8s-1.30/x86_64\nconstraints: < 2.0.0\nregion: eu-west-1",
+	}
+
+	for _, valid := range validYamls {
+		var u SSM
+
+		err := yaml.Unmarshal([]byte(valid), &u)
+		require.NoError(t, err)
+	}
+}
+
+func TestInvalidSSMValues(t *testing.T) {
+	client := mockSSMApi{}
+
+	for _, s := range []SSM{
+		{ServiceClient: client},
+		{Parameter: "/honk", Path: "/honk", ServiceClient: client},
+		{Parameter: "/honk"},
+		{Path: "/honk", Constraints: "bad-constraint", ServiceClient: client},
+	} {
+		_, err := s.LatestVersion()
+		require.Error(t, err)
+	}
+}
+
+func TestSSMParameter(t *testing.T) {
+	s := SSM{
+		Parameter: "/aws/service/eks/optimized-ami/1.30/amazon-linux-2/recommended/image_id",
+		ServiceClient: mockSSMApi{parameters: map[string]string{
+			"/aws/service/eks/optimized-ami/1.30/amazon-linux-2/recommended/image_id": "ami-honk",
+		}},
+	}
+
+	latestVersion, err := s.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "ami-honk", latestVersion)
+
+	s.Parameter = "/parameter/that/does/not/exist"
+	_, err = s.LatestVersion()
+	require.Error(t, err)
+}
+
+func TestSSMPath(t *testing.T) {
+	s := SSM{
+		Path: "/aws/service/bottlerocket/aws-k8s-1.30/x86_64/",
+		ServiceClient: mockSSMApi{pages: [][]types.Parameter{
+			{
+				{Name: aws.String("/aws/service/bottlerocket/aws-k8s-1.30/x86_64/1.19.0/image_id")},
+				{Name: aws.String("/aws/service/bottlerocket/aws-k8s-1.30/x86_64/1.19.0/image_version")},
+				{Name: aws.String("/aws/service/bottlerocket/aws-k8s-1.30/x86_64/latest/image_id")},
+			},
+			{
+				{Name: aws.String("/aws/service/bottlerocket/aws-k8s-1.30/x86_64/1.20.1/image_id")},
+				{Name: aws.String("/aws/service/bottlerocket/aws-k8s-1.30/x86_64/2.0.0/image_id")},
+			},
+		}},
+	}
+
+	latestVersion, err := s.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "2.0.0", latestVersion)
+
+	s.Constraints = "< 2.0.0"
+	latestVersion, err = s.LatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, "1.20.1", latestVersion)
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstream
+
+import (
+	"strings"
+	"time"
+
+	"github.com/blang/semver/v4"
+
+	"sigs.k8s.io/zeitgeist/pkg/container"
+)
+
+// History collects the candidate versions seen by an upstream while looking
+// for the latest version, i.e. after filtering pre-releases, ignored versions
+// and versions too recent for MinAge, so that the staleness of the current
+// version can be measured.
+//
+// Only versions matching the semver constraints of the dependency, if any,
+// are taken into account.
+type History struct {
+	// current version, as comparable with the recorded versions
+	current     string
+	constraints semver.Range
+	versions    []string
+	seen        map[string]bool
+	published   map[string]time.Time
+}
+
+// Staleness measures how far behind the current version of a dependency is.
+type Staleness struct {
+	// NewerReleases is the number of versions newer than the current one
+	NewerReleases int
+	// MajorsBehind is the highest major version distance
+	MajorsBehind int
+	// MinorsBehind is the highest minor version distance within the current
+	// major version
+	MinorsBehind int
+	// PatchesBehind is the highest patch version distance within the current
+	// minor version
+	PatchesBehind int
+	// SupersededAt is when the first newer version was published, zero if
+	// unknown
+	SupersededAt time.Time
+}
+
+// NewHistory creates an empty history for a dependency at currentVersion,
+// with the semver constraints of its upstream, if any. Invalid constraints
+// are reported by the upstream, and ignored here.
+func NewHistory(currentVersion, constraints string) *History {
+	current, _, _ := strings.Cut(currentVersion, container.DigestSeparator)
+
+	h := &History{
+		current:   current,
+		seen:      map[string]bool{},
+		published: map[string]time.Time{},
+	}
+	if constraints != "" {
+		if expectedRange, err := semver.ParseRange(constraints); err == nil {
+			h.constraints = expectedRange
+		}
+	}
+	return h
+}
+
+// recordVersions records candidate versions.
+func (h *History) recordVersions(versions ...string) {
+	if h == nil {
+		return
+	}
+
+	for _, version := range versions {
+		if !h.seen[version] {
+			h.seen[version] = true
+			h.versions = append(h.versions, version)
+		}
+	}
+}
+
+// recordPublished records when releases were published, without making them
+// candidates.
+func (h *History) recordPublished(releases ...Release) {
+	if h == nil {
+		return
+	}
+
+	for _, release := range releases {
+		if !release.Published.IsZero() {
+			h.published[release.Version] = release.Published
+		}
+	}
+}
+
+// Staleness returns how far behind the current version is from the recorded
+// versions, or nil if nothing was recorded or the current version does not
+// follow semver.
+func (h *History) Staleness() *Staleness {
+	if h == nil || len(h.versions) == 0 {
+		return nil
+	}
+
+	current, err := semver.ParseTolerant(h.current)
+	if err != nil {
+		return nil
+	}
+
+	staleness := &Staleness{}
+	for _, version := range h.versions {
+		parsed, err := semver.ParseTolerant(version)
+		if err != nil || !parsed.GT(current) || (h.constraints != nil && !h.constraints(parsed)) {
+			continue
+		}
+
+		staleness.NewerReleases++
+		switch {
+		case parsed.Major > current.Major:
+			staleness.MajorsBehind = max(staleness.MajorsBehind, int(parsed.Major-current.Major))
+		case parsed.Minor > current.Minor:
+			staleness.MinorsBehind = max(staleness.MinorsBehind, int(parsed.Minor-current.Minor))
+		default:
+			staleness.PatchesBehind = max(staleness.PatchesBehind, int(parsed.Patch-current.Patch))
+		}
+
+		published, ok := h.published[version]
+		if ok && (staleness.SupersededAt.IsZero() || published.Before(staleness.SupersededAt)) {
+			staleness.SupersededAt = published
+		}
+	}
+
+	return staleness
+}
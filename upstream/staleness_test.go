@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstream
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryStaleness(t *testing.T) {
+	history := NewHistory("v1.2.3@sha256:0123", "")
+	require.Nil(t, history.Staleness())
+
+	history.recordVersions("v1.2.3", "v1.2.5", "v1.2.4", "v1.4.0", "v3.0.0", "v2.1.0", "v1.0.0", "not-a-version", "v1.2.5")
+	history.recordPublished(
+		Release{Version: "v1.2.4", Published: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		Release{Version: "v1.2.5", Published: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		Release{Version: "v1.0.0", Published: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	)
+
+	require.Equal(t, &Staleness{
+		NewerReleases: 5,
+		MajorsBehind:  2,
+		MinorsBehind:  2,
+		PatchesBehind: 2,
+		SupersededAt:  time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	}, history.Staleness())
+
+	require.Nil(t, NewHistory("latest", "").Staleness())
+
+	// Only versions matching the constraints are counted
+	constrained := NewHistory("v1.2.3", "< 2.0.0")
+	constrained.recordVersions("v1.2.5", "v1.4.0", "v3.0.0", "v2.1.0")
+	require.Equal(t, &Staleness{NewerReleases: 2, MinorsBehind: 2, PatchesBehind: 2}, constrained.Staleness())
+
+	var none *History
+	none.recordVersions("v1.0.0")
+	require.Nil(t, none.Staleness())
+}
+
+func TestGiteaStaleness(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(giteaHandler))
+	defer server.Close()
+	t.Setenv("GITEA_TOKEN", "honk-token")
+
+	g := Gitea{
+		Base:   Base{CurrentVersion: "v1.2.0", History: NewHistory("v1.2.0", "")},
+		Server: server.URL,
+		URL:    "honk/releases",
+	}
+
+	_, err := g.LatestVersion()
+	require.NoError(t, err)
+
+	// Drafts and pre-releases are not counted
+	staleness := g.History.Staleness()
+	require.NotNil(t, staleness)
+	require.Equal(t, 2, staleness.NewerReleases)
+	require.Equal(t, 1, staleness.MajorsBehind)
+	require.Equal(t, 1, staleness.PatchesBehind)
+	require.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), staleness.SupersededAt)
+}
+
+func TestGithubMonorepoStaleness(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(githubEnterpriseHandler))
+	defer server.Close()
+	t.Setenv("GITHUB_TOKEN", "honk-token")
+
+	gh := Github{
+		Base:      Base{CurrentVersion: "v5.3.0", History: NewHistory("v5.3.0", "")},
+		Server:    server.URL,
+		URL:       "honk/monorepo",
+		TagPrefix: "kustomize/",
+	}
+
+	_, err := gh.LatestVersion()
+	require.NoError(t, err)
+
+	// Publication times are known by version, without the tag prefix
+	require.Equal(t, &Staleness{
+		NewerReleases: 1,
+		MinorsBehind:  1,
+		SupersededAt:  time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+	}, gh.History.Staleness())
+}
+
+func TestGitLabMonorepoStaleness(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(gitlabHandler))
+	defer server.Close()
+	t.Setenv("GITLAB_PRIVATE_TOKEN", "honk-token")
+
+	gl := GitLab{
+		Base:       Base{CurrentVersion: "v5.3.0", History: NewHistory("v5.3.0", "")},
+		Server:     server.URL + "/",
+		URL:        "honk/monorepo",
+		TagPattern: `^kustomize/(v.+)$`,
+	}
+
+	_, err := gl.LatestVersion()
+	require.NoError(t, err)
+
+	// Publication times are known by the version captured by the pattern
+	require.Equal(t, &Staleness{
+		NewerReleases: 1,
+		MinorsBehind:  1,
+		SupersededAt:  time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+	}, gl.History.Staleness())
+}
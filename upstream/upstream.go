@@ -26,6 +26,10 @@ package upstream
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/blang/semver/v4"
 	log "github.com/sirupsen/logrus"
@@ -34,16 +38,203 @@ import (
 )
 
 // Base only contains a flavour. "Concrete" upstreams each implement their own fields.
+//
+// The other fields are set from the dependency rather than the upstream
+// configuration.
 type Base struct {
 	Flavour Flavour `yaml:"flavour"`
+
+	// Prereleases is the pre-release policy of the dependency
+	Prereleases PrereleasePolicy `mapstructure:"-" yaml:"-"`
+
+	// CurrentVersion is the current version of the dependency
+	CurrentVersion string `mapstructure:"-" yaml:"-"`
+
+	// MinAge is the minimum time since a version was published for it to be
+	// considered
+	MinAge time.Duration `mapstructure:"-" yaml:"-"`
+
+	// IgnoreVersions are versions or semver ranges which are never
+	// considered, see Ignores
+	IgnoreVersions []string `mapstructure:"-" yaml:"-"`
+
+	// History optionally collects the candidate versions, see History
+	History *History `mapstructure:"-" yaml:"-"`
+
+	// ReleaseNotes optionally collects the release notes of versions, see
+	// ReleaseNotes
+	ReleaseNotes *ReleaseNotes `mapstructure:"-" yaml:"-"`
+}
+
+// Release is a version of an upstream along with its publication time, which
+// is zero if unknown, and its release notes if any.
+type Release struct {
+	Version   string
+	Published time.Time
+	Notes     string
 }
 
+// PrereleasePolicy defines whether pre-release versions, e.g. 1.2.0-rc.1, are
+// considered as the latest version of a dependency.
+type PrereleasePolicy string
+
+const (
+	// PrereleasesExclude never considers pre-releases.
+	PrereleasesExclude PrereleasePolicy = "exclude"
+
+	// PrereleasesInclude considers pre-releases like any other version.
+	PrereleasesInclude PrereleasePolicy = "include"
+
+	// PrereleasesIfCurrent considers pre-releases only if the current version
+	// is a pre-release itself, default.
+	PrereleasesIfCurrent PrereleasePolicy = "only-if-current-is-prerelease"
+)
+
 // LatestVersion will always return an error.
 // Base is only used to determine which actual upstream needs to be called, so it cannot return a sensible value.
 func (u *Base) LatestVersion() (string, error) {
 	return "", errors.New("cannot determine latest version for Base")
 }
 
+// includePrereleases returns whether pre-releases should be considered,
+// depending on the pre-release policy and on whether the current version is
+// a pre-release.
+func (u Base) includePrereleases(currentIsPrerelease bool) (bool, error) {
+	switch u.Prereleases {
+	case PrereleasesExclude:
+		return false, nil
+	case PrereleasesInclude:
+		return true, nil
+	case "", PrereleasesIfCurrent:
+		return currentIsPrerelease, nil
+	default:
+		return false, fmt.Errorf(
+			"invalid prereleases policy %q, must be one of %s, %s or %s",
+			u.Prereleases, PrereleasesExclude, PrereleasesInclude, PrereleasesIfCurrent,
+		)
+	}
+}
+
+// filterVersions removes ignored versions from versions, as well as
+// pre-release versions unless the pre-release policy allows them.
+func (u Base) filterVersions(versions []string) ([]string, error) {
+	include, err := u.includePrereleases(isPrerelease(u.CurrentVersion))
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]string, 0, len(versions))
+	for _, version := range versions {
+		if !include && isPrerelease(version) {
+			log.Debugf("Skipping pre-release: %s", version)
+			continue
+		}
+		if u.Ignores(version) {
+			log.Debugf("Skipping ignored version: %s", version)
+			continue
+		}
+		filtered = append(filtered, version)
+	}
+	u.History.recordVersions(filtered...)
+	return filtered, nil
+}
+
+// Ignores returns whether version is one of IgnoreVersions, either exactly
+// or within one of its semver ranges, e.g. ">= 1.2.0 < 1.2.3".
+func (u Base) Ignores(version string) bool {
+	for _, ignored := range u.IgnoreVersions {
+		if ignored == "" {
+			continue
+		}
+
+		if version == ignored || strings.TrimPrefix(version, "v") == strings.TrimPrefix(ignored, "v") {
+			return true
+		}
+
+		if !strings.ContainsAny(ignored[:1], "<>=!") {
+			continue
+		}
+
+		ignoredRange, err := semver.ParseRange(ignored)
+		if err != nil {
+			log.Debugf("Invalid ignored range %q: %v", ignored, err)
+			continue
+		}
+
+		parsed, err := semver.ParseTolerant(version)
+		if err == nil && ignoredRange(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// tooRecent returns whether a version published at `published` is more
+// recent than MinAge. Versions with an unknown publication time are never
+// too recent.
+func (u Base) tooRecent(published time.Time) bool {
+	return u.MinAge > 0 && !published.IsZero() && time.Since(published) < u.MinAge
+}
+
+// releasedVersions returns the versions of releases published at least MinAge
+// ago.
+func (u Base) releasedVersions(releases []Release) []string {
+	versions := make([]string, 0, len(releases))
+	unknown := 0
+	for _, release := range releases {
+		if u.tooRecent(release.Published) {
+			log.Debugf("Skipping version published less than %s ago: %s (%s)", u.MinAge, release.Version, release.Published)
+			continue
+		}
+		if release.Published.IsZero() {
+			unknown++
+		}
+		u.History.recordPublished(release)
+		versions = append(versions, release.Version)
+	}
+
+	if u.MinAge > 0 && unknown > 0 {
+		log.Warnf("The publication time of %d of %d versions is unknown, minAge is not applied to them", unknown, len(releases))
+	}
+	return versions
+}
+
+// filterReleases returns the versions of releases which are both released,
+// see releasedVersions, and not filtered out, see filterVersions, and records
+// the release notes of these versions only.
+func (u Base) filterReleases(releases []Release) ([]string, error) {
+	versions, err := u.filterVersions(u.releasedVersions(releases))
+	if err != nil {
+		return nil, err
+	}
+
+	if u.ReleaseNotes != nil {
+		kept := make(map[string]bool, len(versions))
+		for _, version := range versions {
+			kept[version] = true
+		}
+		for _, release := range releases {
+			if kept[release.Version] {
+				u.ReleaseNotes.record(release.Version, release.Notes)
+			}
+		}
+	}
+	return versions, nil
+}
+
+// isPrerelease returns whether version is a semver pre-release.
+func isPrerelease(version string) bool {
+	parsed, err := semver.ParseTolerant(version)
+	return err == nil && len(parsed.Pre) > 0
+}
+
+// SkippedVersion is a version which was skipped by an upstream although it
+// would otherwise be the latest version, along with the reason why.
+type SkippedVersion struct {
+	Version string
+	Reason  string
+}
+
 // Flavour is an enum of all supported upstreams and their string representation.
 type Flavour string
 
@@ -54,9 +245,21 @@ const (
 	// GitLabFlavour is for GitLab releases.
 	GitLabFlavour Flavour = "gitlab"
 
+	// GiteaFlavour is for Gitea releases.
+	GiteaFlavour Flavour = "gitea"
+
+	// ForgejoFlavour is for Forgejo releases, which share the Gitea API.
+	ForgejoFlavour Flavour = "forgejo"
+
+	// BitbucketFlavour is for Bitbucket tags.
+	BitbucketFlavour Flavour = "bitbucket"
+
 	// AMIFlavour is for Amazon Machine Images.
 	AMIFlavour Flavour = "ami"
 
+	// SSMFlavour is for AWS Systems Manager parameters.
+	SSMFlavour Flavour = "ssm"
+
 	// HelmFlavour is for Helm Charts.
 	HelmFlavour Flavour = "helm"
 
@@ -66,6 +269,9 @@ const (
 	// EKSFlavour is for Elastic Kubernetes Service.
 	EKSFlavour Flavour = "eks"
 
+	// HTTPFlavour is for versions scraped from a web page or a JSON endpoint.
+	HTTPFlavour Flavour = "http"
+
 	// DummyFlavour is for testing.
 	DummyFlavour Flavour = "dummy"
 
@@ -103,3 +309,60 @@ func selectHighestVersion(constraints string, expectedRange semver.Range, tags [
 	// No latest version found – no versions? Only prereleases?
 	return "", errors.New("no potential version found")
 }
+
+// componentReleases selects the releases of a single component of a
+// monorepo, tagged e.g. kustomize/v5.4.1 and api/v0.17.0, and returns them
+// with the version part of their tag as Version, so that their publication
+// time and release notes are known by version.
+//
+// Tags are selected either by tagPrefix, which is stripped from the tag, or by
+// tagPattern, a regular expression whose `version` named group (or first group
+// if there is none) holds the version. All releases are returned unchanged if
+// neither is set.
+func componentReleases(tagPrefix, tagPattern string, releases []Release) ([]Release, error) {
+	if tagPrefix != "" && tagPattern != "" {
+		return nil, errors.New("only one of tagPrefix and tagPattern can be set")
+	}
+
+	if tagPrefix != "" {
+		selected := []Release{}
+		for _, release := range releases {
+			if version, ok := strings.CutPrefix(release.Version, tagPrefix); ok {
+				release.Version = version
+				selected = append(selected, release)
+			} else {
+				log.Debugf("Skipping tag not matching prefix (%s): %s", tagPrefix, release.Version)
+			}
+		}
+		return selected, nil
+	}
+
+	if tagPattern != "" {
+		pattern, err := regexp.Compile(tagPattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tagPattern: %w", err)
+		}
+		if pattern.NumSubexp() == 0 {
+			return nil, fmt.Errorf("invalid tagPattern %s: missing a group capturing the version", tagPattern)
+		}
+
+		group := pattern.SubexpIndex("version")
+		if group == -1 {
+			group = 1
+		}
+
+		selected := []Release{}
+		for _, release := range releases {
+			match := pattern.FindStringSubmatch(release.Version)
+			if match == nil || match[group] == "" {
+				log.Debugf("Skipping tag not matching pattern (%s): %s", tagPattern, release.Version)
+				continue
+			}
+			release.Version = match[group]
+			selected = append(selected, release)
+		}
+		return selected, nil
+	}
+
+	return releases, nil
+}
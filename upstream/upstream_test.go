@@ -18,6 +18,7 @@ package upstream
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
@@ -34,3 +35,127 @@ func TestBaseLatestVersion(t *testing.T) {
 	_, err = u.LatestVersion()
 	require.Error(t, err)
 }
+
+func TestComponentReleases(t *testing.T) {
+	tags := []string{"kustomize/v5.4.1", "kustomize/v5.3.0", "api/v0.17.0", "v1.0.0", "kyaml/v0.17.1"}
+
+	for _, tc := range []struct {
+		name       string
+		tagPrefix  string
+		tagPattern string
+		expected   []string
+		shouldErr  bool
+	}{
+		{
+			name:     "no filter",
+			expected: tags,
+		},
+		{
+			name:      "prefix",
+			tagPrefix: "kustomize/",
+			expected:  []string{"v5.4.1", "v5.3.0"},
+		},
+		{
+			name:       "pattern with named group",
+			tagPattern: `^(api|kyaml)/(?P<version>v.+)$`,
+			expected:   []string{"v0.17.0", "v0.17.1"},
+		},
+		{
+			name:       "pattern with unnamed group",
+			tagPattern: `^kustomize/v(.+)$`,
+			expected:   []string{"5.4.1", "5.3.0"},
+		},
+		{
+			name:       "pattern without group",
+			tagPattern: `^kustomize/`,
+			shouldErr:  true,
+		},
+		{
+			name:       "invalid pattern",
+			tagPattern: `^kustomize/(`,
+			shouldErr:  true,
+		},
+		{
+			name:       "both prefix and pattern",
+			tagPrefix:  "kustomize/",
+			tagPattern: `^kustomize/(.+)$`,
+			shouldErr:  true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			releases := make([]Release, 0, len(tags))
+			for _, tag := range tags {
+				releases = append(releases, Release{Version: tag, Notes: "Notes of " + tag})
+			}
+
+			selected, err := componentReleases(tc.tagPrefix, tc.tagPattern, releases)
+			if tc.shouldErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			versions := make([]string, 0, len(selected))
+			for _, release := range selected {
+				versions = append(versions, release.Version)
+				// The rest of the release is kept
+				require.Contains(t, release.Notes, release.Version)
+			}
+			require.Equal(t, tc.expected, versions)
+		})
+	}
+}
+
+func TestFilterPrereleases(t *testing.T) {
+	versions := []string{"v1.0.0", "v1.1.0-rc.1", "1.2.0-alpha", "not-a-version"}
+	stable := []string{"v1.0.0", "not-a-version"}
+
+	for _, tc := range []struct {
+		base      Base
+		expected  []string
+		shouldErr bool
+	}{
+		{base: Base{}, expected: stable},
+		{base: Base{CurrentVersion: "v1.0.0"}, expected: stable},
+		{base: Base{CurrentVersion: "v1.0.0-rc.2"}, expected: versions},
+		{base: Base{CurrentVersion: "v1.0.0", Prereleases: PrereleasesIfCurrent}, expected: stable},
+		{base: Base{CurrentVersion: "v1.0.0-rc.2", Prereleases: PrereleasesExclude}, expected: stable},
+		{base: Base{CurrentVersion: "v1.0.0", Prereleases: PrereleasesInclude}, expected: versions},
+		{base: Base{Prereleases: "sometimes"}, shouldErr: true},
+	} {
+		filtered, err := tc.base.filterVersions(versions)
+		if tc.shouldErr {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, filtered, "%+v", tc.base)
+	}
+}
+
+func TestReleasedVersions(t *testing.T) {
+	releases := []Release{
+		{Version: "1.0.0", Published: time.Now().Add(-30 * 24 * time.Hour)},
+		{Version: "1.1.0"},
+		{Version: "1.2.0", Published: time.Now().Add(-time.Hour)},
+	}
+
+	require.Equal(t, []string{"1.0.0", "1.1.0", "1.2.0"}, Base{}.releasedVersions(releases))
+	require.Equal(t, []string{"1.0.0", "1.1.0"}, Base{MinAge: 7 * 24 * time.Hour}.releasedVersions(releases))
+}
+
+func TestIgnores(t *testing.T) {
+	b := Base{IgnoreVersions: []string{"v1.2.3", "ami-0123", ">= 2.0.0 < 2.0.2", "> not-a-range"}}
+
+	for _, ignored := range []string{"v1.2.3", "1.2.3", "ami-0123", "2.0.0", "v2.0.1"} {
+		require.True(t, b.Ignores(ignored), ignored)
+	}
+
+	for _, kept := range []string{"1.2.4", "ami-4567", "2.0.2", "1.9.0"} {
+		require.False(t, b.Ignores(kept), kept)
+	}
+
+	filtered, err := b.filterVersions([]string{"1.2.3", "1.2.4", "2.0.1", "2.0.2"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"1.2.4", "2.0.2"}, filtered)
+}